@@ -0,0 +1,215 @@
+package mp4
+
+import (
+	"fmt"
+)
+
+// ISO/IEC 14496‐3 1.6.2.1 AudioSpecificConfig
+
+// The AudioSpecificConfig is the decoder specific information of MPEG‐4 audio
+// streams, carried in the DecoderSpecificInfo of the ES_Descriptor. It
+// identifies the audio object type, the sampling frequency and the channel
+// configuration of the stream. High Efficiency AAC streams either signal SBR
+// and PS explicitly by using audio object type 5 or 29 followed by the core
+// object type, or implicitly in a backward compatible sync extension
+// following the core configuration.
+type AudioSpecificConfig struct {
+	// is the audio object type of the core coder, e.g. 2 for AAC LC.
+	AudioObjectType AudioObjectType
+
+	SamplingFrequencyIndex uint8
+
+	// is the sampling frequency of the core coder in Hz.
+	SamplingFrequency uint32
+
+	ChannelConfiguration uint8
+
+	// is 5 (SBR) if spectral band replication is signalled, or 0.
+	ExtensionAudioObjectType AudioObjectType
+
+	SBRPresent bool
+	PSPresent  bool
+
+	ExtensionSamplingFrequencyIndex uint8
+
+	// is the output sampling frequency in Hz if SBR is present.
+	ExtensionSamplingFrequency uint32
+
+	// GASpecificConfig fields, present for AAC object types.
+	FrameLengthFlag    bool
+	DependsOnCoreCoder bool
+	CoreCoderDelay     uint16
+	ExtensionFlag      bool
+}
+
+type AudioObjectType uint8
+
+const (
+	AudioObjectTypeNull     AudioObjectType = 0
+	AudioObjectTypeAACMain  AudioObjectType = 1
+	AudioObjectTypeAACLC    AudioObjectType = 2
+	AudioObjectTypeAACSSR   AudioObjectType = 3
+	AudioObjectTypeAACLTP   AudioObjectType = 4
+	AudioObjectTypeSBR      AudioObjectType = 5
+	AudioObjectTypeAACScal  AudioObjectType = 6
+	AudioObjectTypeERAACLC  AudioObjectType = 17
+	AudioObjectTypeERAACLD  AudioObjectType = 23
+	AudioObjectTypePS       AudioObjectType = 29
+	AudioObjectTypeLayer1   AudioObjectType = 32
+	AudioObjectTypeLayer2   AudioObjectType = 33
+	AudioObjectTypeLayer3   AudioObjectType = 34
+	AudioObjectTypeALS      AudioObjectType = 36
+	AudioObjectTypeERAACELD AudioObjectType = 39
+	AudioObjectTypeUSAC     AudioObjectType = 42
+)
+
+var aacSamplingFrequencies = [...]uint32{
+	96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350,
+}
+
+var aacChannelConfigurationChannels = [...]uint8{
+	0, 1, 2, 3, 4, 5, 6, 8, 0, 0, 0, 7, 8, 24,
+}
+
+func ParseAudioSpecificConfig(data []byte) (config *AudioSpecificConfig, err error) {
+	c := &AudioSpecificConfig{}
+	r := newBitReader(data)
+	c.AudioObjectType = readAudioObjectType(r)
+	c.SamplingFrequencyIndex, c.SamplingFrequency = readAACSamplingFrequency(r)
+	c.ChannelConfiguration = uint8(r.read(4))
+	if c.AudioObjectType == AudioObjectTypeSBR || c.AudioObjectType == AudioObjectTypePS {
+		c.ExtensionAudioObjectType = AudioObjectTypeSBR
+		c.SBRPresent = true
+		c.PSPresent = c.AudioObjectType == AudioObjectTypePS
+		c.ExtensionSamplingFrequencyIndex, c.ExtensionSamplingFrequency = readAACSamplingFrequency(r)
+		c.AudioObjectType = readAudioObjectType(r)
+		if c.AudioObjectType == 22 {
+			r.skip(4) // extensionChannelConfiguration;
+		}
+	}
+	// the sync extension can only be located if everything preceding it has
+	// been interpreted.
+	var extensible bool
+	switch c.AudioObjectType {
+	case 1, 2, 3, 4, 6, 7, 17, 19, 20, 21, 22, 23:
+		extensible = c.readGASpecificConfig(r)
+	}
+	if r.err != nil {
+		err = fmt.Errorf("invalid AudioSpecificConfig: %w", r.err)
+		return
+	}
+	if extensible && c.ExtensionAudioObjectType != AudioObjectTypeSBR && r.remaining() >= 16 {
+		syncExtensionType := r.read(11)
+		if syncExtensionType == 0x2B7 {
+			extensionAudioObjectType := readAudioObjectType(r)
+			if extensionAudioObjectType == AudioObjectTypeSBR {
+				c.ExtensionAudioObjectType = extensionAudioObjectType
+				c.SBRPresent = r.readFlag()
+				if c.SBRPresent {
+					c.ExtensionSamplingFrequencyIndex, c.ExtensionSamplingFrequency = readAACSamplingFrequency(r)
+					if r.remaining() >= 12 {
+						syncExtensionType = r.read(11)
+						if syncExtensionType == 0x548 {
+							c.PSPresent = r.readFlag()
+						}
+					}
+				}
+			}
+		}
+		if r.err != nil {
+			err = fmt.Errorf("invalid AudioSpecificConfig sync extension: %w", r.err)
+			return
+		}
+	}
+	config = c
+	return
+}
+
+// readGASpecificConfig reads the GASpecificConfig and the error protection
+// configuration. It returns false if a program_config_element() or an
+// ErrorProtectionSpecificConfig() follows, which are not interpreted.
+func (c *AudioSpecificConfig) readGASpecificConfig(r *bitReader) bool {
+	c.FrameLengthFlag = r.readFlag()
+	c.DependsOnCoreCoder = r.readFlag()
+	if c.DependsOnCoreCoder {
+		c.CoreCoderDelay = uint16(r.read(14))
+	}
+	c.ExtensionFlag = r.readFlag()
+	if c.ChannelConfiguration == 0 {
+		return false
+	}
+	if c.AudioObjectType == 6 || c.AudioObjectType == 20 {
+		r.skip(3) // layerNr;
+	}
+	if c.ExtensionFlag {
+		if c.AudioObjectType == 22 {
+			r.skip(5)  // numOfSubFrame;
+			r.skip(11) // layer_length;
+		}
+		switch c.AudioObjectType {
+		case 17, 19, 20, 23:
+			r.skip(1) // aacSectionDataResilienceFlag;
+			r.skip(1) // aacScalefactorDataResilienceFlag;
+			r.skip(1) // aacSpectralDataResilienceFlag;
+		}
+		r.skip(1) // extensionFlag3;
+	}
+	switch c.AudioObjectType {
+	case 17, 19, 20, 21, 22, 23:
+		epConfig := r.read(2)
+		if epConfig == 2 || epConfig == 3 {
+			return false
+		}
+	}
+	return true
+}
+
+func readAudioObjectType(r *bitReader) AudioObjectType {
+	audioObjectType := r.read(5)
+	if audioObjectType == 31 {
+		audioObjectType = 32 + r.read(6)
+	}
+	return AudioObjectType(audioObjectType)
+}
+
+func readAACSamplingFrequency(r *bitReader) (index uint8, frequency uint32) {
+	index = uint8(r.read(4))
+	if index == 0xF {
+		frequency = uint32(r.read(24))
+	} else if int(index) < len(aacSamplingFrequencies) {
+		frequency = aacSamplingFrequencies[index]
+	}
+	return
+}
+
+// ObjectType returns the effective audio object type of the stream: 29 for
+// HE‐AAC v2 (SBR and PS), 5 for HE‐AAC (SBR) and the core object type
+// otherwise. This is the value used in the ‘mp4a.40.x’ codec string.
+func (c *AudioSpecificConfig) ObjectType() AudioObjectType {
+	if c.SBRPresent && c.PSPresent {
+		return AudioObjectTypePS
+	}
+	if c.SBRPresent {
+		return AudioObjectTypeSBR
+	}
+	return c.AudioObjectType
+}
+
+// OutputSamplingFrequency returns the sampling frequency of the decoded
+// output, which is the extension sampling frequency if SBR is present.
+func (c *AudioSpecificConfig) OutputSamplingFrequency() uint32 {
+	if c.SBRPresent && c.ExtensionSamplingFrequency != 0 {
+		return c.ExtensionSamplingFrequency
+	}
+	return c.SamplingFrequency
+}
+
+// ChannelCount returns the number of channels implied by the channel
+// configuration, or 0 if the configuration is carried in a program config
+// element or reserved.
+func (c *AudioSpecificConfig) ChannelCount() uint8 {
+	if int(c.ChannelConfiguration) < len(aacChannelConfigurationChannels) {
+		return aacChannelConfigurationChannels[c.ChannelConfiguration]
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+package mp4
+
+import (
+	"fmt"
+)
+
+// bitReader reads big‐endian bit fields from a byte slice. The first error is
+// kept and all later reads return zero, so that a field sequence can be read
+// before checking err once.
+type bitReader struct {
+	data []byte
+	pos  int // in bits
+	err  error
+}
+
+func newBitReader(data []byte) *bitReader {
+	return &bitReader{data: data}
+}
+
+func (r *bitReader) read(n int) (v uint64) {
+	if r.err != nil {
+		return
+	}
+	if r.pos+n > len(r.data)*8 {
+		r.err = fmt.Errorf("bit field exceeds data boundary: %w", ErrInvalidFormat)
+		return
+	}
+	for i := 0; i < n; i++ {
+		v = v<<1 | uint64(r.data[r.pos>>3]>>(7-r.pos&7))&1
+		r.pos++
+	}
+	return
+}
+
+func (r *bitReader) readFlag() bool {
+	return r.read(1) > 0
+}
+
+func (r *bitReader) skip(n int) {
+	r.read(n)
+}
+
+// remaining returns the number of unread bits.
+func (r *bitReader) remaining() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *bitReader) byteAlign() {
+	if r.pos&7 != 0 {
+		r.skip(8 - r.pos&7)
+	}
+}
+
+// rest returns the unread bytes after aligning to the next byte boundary.
+func (r *bitReader) rest() []byte {
+	r.byteAlign()
+	if r.err != nil {
+		return nil
+	}
+	return append([]byte(nil), r.data[r.pos>>3:]...)
+}
+
+// bitWriter collects big‐endian bit fields into a byte slice.
+type bitWriter struct {
+	data []byte
+	pos  int // in bits
+}
+
+func (w *bitWriter) write(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.pos&7 == 0 {
+			w.data = append(w.data, 0)
+		}
+		w.data[w.pos>>3] |= uint8((v>>i)&1) << (7 - w.pos&7)
+		w.pos++
+	}
+}
+
+func (w *bitWriter) writeFlag(flag bool) {
+	if flag {
+		w.write(1, 1)
+	} else {
+		w.write(0, 1)
+	}
+}
+
+// byteAlign zero pads the data to the next byte boundary.
+func (w *bitWriter) byteAlign() {
+	w.pos = len(w.data) * 8
+}
+
+// writeBytes appends p after aligning to the next byte boundary.
+func (w *bitWriter) writeBytes(p []byte) {
+	w.byteAlign()
+	w.data = append(w.data, p...)
+	w.pos = len(w.data) * 8
+}
+
+// bytes returns the written data, zero padded to a byte boundary.
+func (w *bitWriter) bytes() []byte {
+	return w.data
+}
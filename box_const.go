@@ -5,46 +5,86 @@ type FourCC [4]byte
 type BoxType FourCC
 
 var (
+	Av1CBoxType = BoxType{'a', 'v', '1', 'C'}
+	AuthBoxType = BoxType{'a', 'u', 't', 'h'}
 	AvcCBoxType = BoxType{'a', 'v', 'c', 'C'}
 	AvcEBoxType = BoxType{'a', 'v', 'c', 'E'}
 	BtrtBoxType = BoxType{'b', 't', 'r', 't'}
+	ChplBoxType = BoxType{'c', 'h', 'p', 'l'}
 	ClapBoxType = BoxType{'c', 'l', 'a', 'p'}
+	ClliBoxType = BoxType{'c', 'l', 'l', 'i'}
+	Co64BoxType = BoxType{'c', 'o', '6', '4'}
+	CoLLBoxType = BoxType{'C', 'o', 'L', 'L'}
 	ColrBoxType = BoxType{'c', 'o', 'l', 'r'}
+	CprtBoxType = BoxType{'c', 'p', 'r', 't'}
+	CtimBoxType = BoxType{'c', 't', 'i', 'm'}
 	CttsBoxType = BoxType{'c', 't', 't', 's'}
+	Dac3BoxType = BoxType{'d', 'a', 'c', '3'}
+	Dac4BoxType = BoxType{'d', 'a', 'c', '4'}
+	DataBoxType = BoxType{'d', 'a', 't', 'a'}
+	Dec3BoxType = BoxType{'d', 'e', 'c', '3'}
+	DfLaBoxType = BoxType{'d', 'f', 'L', 'a'}
 	DinfBoxType = BoxType{'d', 'i', 'n', 'f'}
+	DOpsBoxType = BoxType{'d', 'O', 'p', 's'}
 	DrefBoxType = BoxType{'d', 'r', 'e', 'f'}
+	DscpBoxType = BoxType{'d', 's', 'c', 'p'}
 	DvcCBoxType = BoxType{'d', 'v', 'c', 'C'}
 	DvvCBoxType = BoxType{'d', 'v', 'v', 'C'}
 	DvwCBoxType = BoxType{'d', 'v', 'w', 'C'}
+	EdtsBoxType = BoxType{'e', 'd', 't', 's'}
 	ElngBoxType = BoxType{'e', 'l', 'n', 'g'}
+	ElstBoxType = BoxType{'e', 'l', 's', 't'}
 	EncaBoxType = BoxType{'e', 'n', 'c', 'a'}
 	EncsBoxType = BoxType{'e', 'n', 'c', 's'}
 	EnctBoxType = BoxType{'e', 'n', 'c', 't'}
 	EncvBoxType = BoxType{'e', 'n', 'c', 'v'}
+	EsdsBoxType = BoxType{'e', 's', 'd', 's'}
 	FreeBoxType = BoxType{'f', 'r', 'e', 'e'}
 	FrmaBoxType = BoxType{'f', 'r', 'm', 'a'}
+	FtabBoxType = BoxType{'f', 't', 'a', 'b'}
 	FtypBoxType = BoxType{'f', 't', 'y', 'p'}
+	GmhdBoxType = BoxType{'g', 'm', 'h', 'd'}
+	GminBoxType = BoxType{'g', 'm', 'i', 'n'}
+	GnreBoxType = BoxType{'g', 'n', 'r', 'e'}
 	HdlrBoxType = BoxType{'h', 'd', 'l', 'r'}
 	HvcCBoxType = BoxType{'h', 'v', 'c', 'C'}
 	HvcEBoxType = BoxType{'h', 'v', 'c', 'E'}
+	IdenBoxType = BoxType{'i', 'd', 'e', 'n'}
+	IlstBoxType = BoxType{'i', 'l', 's', 't'}
+	KeysBoxType = BoxType{'k', 'e', 'y', 's'}
+	KindBoxType = BoxType{'k', 'i', 'n', 'd'}
+	LablBoxType = BoxType{'l', 'a', 'b', 'l'}
 	MdatBoxType = BoxType{'m', 'd', 'a', 't'}
+	MdcvBoxType = BoxType{'m', 'd', 'c', 'v'}
 	MdhdBoxType = BoxType{'m', 'd', 'h', 'd'}
 	MdiaBoxType = BoxType{'m', 'd', 'i', 'a'}
+	MeanBoxType = BoxType{'m', 'e', 'a', 'n'}
+	MehdBoxType = BoxType{'m', 'e', 'h', 'd'}
+	MetaBoxType = BoxType{'m', 'e', 't', 'a'}
 	MfhdBoxType = BoxType{'m', 'f', 'h', 'd'}
 	MinfBoxType = BoxType{'m', 'i', 'n', 'f'}
 	MoofBoxType = BoxType{'m', 'o', 'o', 'f'}
 	MoovBoxType = BoxType{'m', 'o', 'o', 'v'}
 	MvexBoxType = BoxType{'m', 'v', 'e', 'x'}
 	MvhdBoxType = BoxType{'m', 'v', 'h', 'd'}
+	NameBoxType = BoxType{'n', 'a', 'm', 'e'}
 	NmhdBoxType = BoxType{'n', 'm', 'h', 'd'}
+	PaylBoxType = BoxType{'p', 'a', 'y', 'l'}
 	PaspBoxType = BoxType{'p', 'a', 's', 'p'}
+	PerfBoxType = BoxType{'p', 'e', 'r', 'f'}
 	PsshBoxType = BoxType{'p', 's', 's', 'h'}
+	ResvBoxType = BoxType{'r', 'e', 's', 'v'}
+	RinfBoxType = BoxType{'r', 'i', 'n', 'f'}
 	SaioBoxType = BoxType{'s', 'a', 'i', 'o'}
 	SaizBoxType = BoxType{'s', 'a', 'i', 'z'}
+	SbgpBoxType = BoxType{'s', 'b', 'g', 'p'}
 	SchiBoxType = BoxType{'s', 'c', 'h', 'i'}
 	SchmBoxType = BoxType{'s', 'c', 'h', 'm'}
+	SdtpBoxType = BoxType{'s', 'd', 't', 'p'}
 	SencBoxType = BoxType{'s', 'e', 'n', 'c'}
+	SgpdBoxType = BoxType{'s', 'g', 'p', 'd'}
 	SinfBoxType = BoxType{'s', 'i', 'n', 'f'}
+	SmDmBoxType = BoxType{'S', 'm', 'D', 'm'}
 	SmhdBoxType = BoxType{'s', 'm', 'h', 'd'}
 	StblBoxType = BoxType{'s', 't', 'b', 'l'}
 	StcoBoxType = BoxType{'s', 't', 'c', 'o'}
@@ -53,18 +93,36 @@ var (
 	StsdBoxType = BoxType{'s', 't', 's', 'd'}
 	StssBoxType = BoxType{'s', 't', 's', 's'}
 	StszBoxType = BoxType{'s', 't', 's', 'z'}
+	SthdBoxType = BoxType{'s', 't', 'h', 'd'}
+	SttgBoxType = BoxType{'s', 't', 't', 'g'}
 	SttsBoxType = BoxType{'s', 't', 't', 's'}
+	TcmiBoxType = BoxType{'t', 'c', 'm', 'i'}
 	TencBoxType = BoxType{'t', 'e', 'n', 'c'}
+	TfdtBoxType = BoxType{'t', 'f', 'd', 't'}
 	TfhdBoxType = BoxType{'t', 'f', 'h', 'd'}
+	TitlBoxType = BoxType{'t', 'i', 't', 'l'}
 	TkhdBoxType = BoxType{'t', 'k', 'h', 'd'}
 	TrakBoxType = BoxType{'t', 'r', 'a', 'k'}
 	TrafBoxType = BoxType{'t', 'r', 'a', 'f'}
 	TrexBoxType = BoxType{'t', 'r', 'e', 'x'}
+	TrefBoxType = BoxType{'t', 'r', 'e', 'f'}
+	TrgrBoxType = BoxType{'t', 'r', 'g', 'r'}
 	TrunBoxType = BoxType{'t', 'r', 'u', 'n'}
+	UdtaBoxType = BoxType{'u', 'd', 't', 'a'}
 	UuidBoxType = BoxType{'u', 'u', 'i', 'd'}
 	UrlBoxType  = BoxType{'u', 'r', 'l', ' '}
 	UrnBoxType  = BoxType{'u', 'r', 'n', ' '}
+	VlabBoxType = BoxType{'v', 'l', 'a', 'b'}
 	VmhdBoxType = BoxType{'v', 'm', 'h', 'd'}
+	VpcCBoxType = BoxType{'v', 'p', 'c', 'C'}
+	VsidBoxType = BoxType{'v', 's', 'i', 'd'}
+	VttCBoxType = BoxType{'v', 't', 't', 'C'}
+	VttaBoxType = BoxType{'v', 't', 't', 'a'}
+	VttcBoxType = BoxType{'v', 't', 't', 'c'}
+	VtteBoxType = BoxType{'v', 't', 't', 'e'}
+	VvcCBoxType = BoxType{'v', 'v', 'c', 'C'}
+	XmlBoxType  = BoxType{'x', 'm', 'l', ' '}
+	YrrcBoxType = BoxType{'y', 'r', 'r', 'c'}
 
 	DvavBoxType = BoxType{'d', 'v', 'a', 'v'}
 	Dva1BoxType = BoxType{'d', 'v', 'a', '1'}
@@ -76,7 +134,45 @@ var (
 	Avc4BoxType = BoxType{'a', 'v', 'c', '4'}
 	Hev1BoxType = BoxType{'h', 'e', 'v', '1'}
 	Hvc1BoxType = BoxType{'h', 'v', 'c', '1'}
+	Vp09BoxType = BoxType{'v', 'p', '0', '9'}
+	Av01BoxType = BoxType{'a', 'v', '0', '1'}
+	Vvc1BoxType = BoxType{'v', 'v', 'c', '1'}
+	Vvi1BoxType = BoxType{'v', 'v', 'i', '1'}
+	Mp4aBoxType = BoxType{'m', 'p', '4', 'a'}
+	Ac3BoxType  = BoxType{'a', 'c', '-', '3'}
+	Ec3BoxType  = BoxType{'e', 'c', '-', '3'}
+	Ac4BoxType  = BoxType{'a', 'c', '-', '4'}
+	OpusBoxType = BoxType{'O', 'p', 'u', 's'}
+	FLaCBoxType = BoxType{'f', 'L', 'a', 'C'}
+	AlacBoxType = BoxType{'a', 'l', 'a', 'c'}
+	WvttBoxType = BoxType{'w', 'v', 't', 't'}
+	StppBoxType = BoxType{'s', 't', 'p', 'p'}
+	Tx3gBoxType = BoxType{'t', 'x', '3', 'g'}
 
+	AuxlBoxType = BoxType{'a', 'u', 'x', 'l'}
+	CdscBoxType = BoxType{'c', 'd', 's', 'c'}
+	ChapBoxType = BoxType{'c', 'h', 'a', 'p'}
+	DpndBoxType = BoxType{'d', 'p', 'n', 'd'}
+	FontBoxType = BoxType{'f', 'o', 'n', 't'}
+	ForcBoxType = BoxType{'f', 'o', 'r', 'c'}
+	HindBoxType = BoxType{'h', 'i', 'n', 'd'}
+	HintBoxType = BoxType{'h', 'i', 'n', 't'}
+	IpirBoxType = BoxType{'i', 'p', 'i', 'r'}
+	MpodBoxType = BoxType{'m', 'p', 'o', 'd'}
+	SbtlBoxType = BoxType{'s', 'b', 't', 'l'}
+	ScptBoxType = BoxType{'s', 'c', 'p', 't'}
+	SsrcBoxType = BoxType{'s', 's', 'r', 'c'}
+	SubtBoxType = BoxType{'s', 'u', 'b', 't'}
+	SyncBoxType = BoxType{'s', 'y', 'n', 'c'}
+	ThmbBoxType = BoxType{'t', 'h', 'm', 'b'}
+	TmcdBoxType = BoxType{'t', 'm', 'c', 'd'}
+	VdepBoxType = BoxType{'v', 'd', 'e', 'p'}
+	VplxBoxType = BoxType{'v', 'p', 'l', 'x'}
+
+	MsrcBoxType = BoxType{'m', 's', 'r', 'c'}
+	SterBoxType = BoxType{'s', 't', 'e', 'r'}
+
+	Av01FourCC = FourCC{'a', 'v', '0', '1'}
 	Avc1FourCC = FourCC{'a', 'v', 'c', '1'}
 	Avc2FourCC = FourCC{'a', 'v', 'c', '2'}
 	Avc3FourCC = FourCC{'a', 'v', 'c', '3'}
@@ -94,14 +190,24 @@ var (
 	Iso6FourCC = FourCC{'i', 's', 'o', '6'}
 	IsomFourCC = FourCC{'i', 's', 'o', 'm'}
 	MetaFourCC = FourCC{'m', 'e', 't', 'a'}
+	MdirFourCC = FourCC{'m', 'd', 'i', 'r'}
+	MdtaFourCC = FourCC{'m', 'd', 't', 'a'}
+	Mp41FourCC = FourCC{'m', 'p', '4', '1'}
+	Mp4aFourCC = FourCC{'m', 'p', '4', 'a'}
 	MsdhFourCC = FourCC{'m', 's', 'd', 'h'}
+	SbtlFourCC = FourCC{'s', 'b', 't', 'l'}
 	SounFourCC = FourCC{'s', 'o', 'u', 'n'}
+	SubtFourCC = FourCC{'s', 'u', 'b', 't'}
+	TextFourCC = FourCC{'t', 'e', 'x', 't'}
+	TmcdFourCC = FourCC{'t', 'm', 'c', 'd'}
 	VideFourCC = FourCC{'v', 'i', 'd', 'e'}
+	Vp09FourCC = FourCC{'v', 'p', '0', '9'}
 
 	NclcFourCC = FourCC{'n', 'c', 'l', 'c'}
 	NclxFourCC = FourCC{'n', 'c', 'l', 'x'}
 	RiccFourCC = FourCC{'r', 'I', 'C', 'C'}
 	ProfFourCC = FourCC{'p', 'r', 'o', 'f'}
 
+	XMPBoxUserType              = UserType{0xBE, 0x7A, 0xCF, 0xCB, 0x97, 0xA9, 0x42, 0xE8, 0x9C, 0x71, 0x99, 0x94, 0x91, 0xE3, 0xAF, 0xAC}
 	SampleEncryptionBoxUserType = UserType{0xA2, 0x39, 0x4F, 0x52, 0x5A, 0x9B, 0x4F, 0x14, 0xA2, 0x44, 0x6C, 0x42, 0x7C, 0x64, 0x8D, 0xF4}
 )
@@ -0,0 +1,71 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// VP Codec ISO Media File Format Binding 2.6 Content Light Level Box
+
+// Box Type: ‘CoLL’
+// Container: Visual Sample Entry
+// Mandatory: No
+// Quantity: Zero or one
+
+// The VPContentLightLevelBox is the full box predecessor of the
+// ContentLightLevelBox used by VP9 and early AV1 files, with the same
+// semantics as CTA‐861.3.
+type VPContentLightLevelBox struct {
+	FullHeader
+	NullContainer
+
+	// is the maximum content light level (MaxCLL) in cd/m².
+	MaxCLL uint16
+
+	// is the maximum frame average light level (MaxFALL) in cd/m².
+	MaxFALL uint16
+}
+
+var _ Box = (*VPContentLightLevelBox)(nil)
+
+func init() {
+	BoxRegistry[CoLLBoxType] = func() Box { return &VPContentLightLevelBox{} }
+}
+
+func (b VPContentLightLevelBox) Mp4BoxType() BoxType {
+	return CoLLBoxType
+}
+
+func (b *VPContentLightLevelBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 2 // unsigned int(16) maxCLL;
+	b.Size += 2 // unsigned int(16) maxFALL;
+	return b.Size
+}
+
+func (b *VPContentLightLevelBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MaxCLL); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MaxFALL); err != nil {
+		return
+	}
+	return
+}
+
+func (b *VPContentLightLevelBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxCLL); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxFALL); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,156 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// VP Codec ISO Media File Format Binding 2.5 Mastering Display Metadata Box
+
+// Box Type: ‘SmDm’
+// Container: Visual Sample Entry
+// Mandatory: No
+// Quantity: Zero or one
+
+// The SMPTE2086MasteringDisplayMetadataBox is the full box predecessor of the
+// MasteringDisplayColourVolumeBox used by VP9 and early AV1 files, with the
+// semantics of SMPTE ST 2086. Unlike ‘mdcv’ the primaries are in the order
+// red, green and blue, and the values use fixed point formats.
+type SMPTE2086MasteringDisplayMetadataBox struct {
+	FullHeader
+	NullContainer
+
+	PrimaryR   SmDmChromaticity
+	PrimaryG   SmDmChromaticity
+	PrimaryB   SmDmChromaticity
+	WhitePoint SmDmChromaticity
+
+	LuminanceMax SmDmLuminanceMax
+	LuminanceMin SmDmLuminanceMin
+}
+
+// SmDmChromaticity is a chromaticity coordinate in 0.16 fixed point.
+type SmDmChromaticity struct {
+	X uint16
+	Y uint16
+}
+
+// SmDmLuminanceMax is a luminance in cd/m² in 24.8 fixed point.
+type SmDmLuminanceMax uint32
+
+// SmDmLuminanceMin is a luminance in cd/m² in 18.14 fixed point.
+type SmDmLuminanceMin uint32
+
+var _ Box = (*SMPTE2086MasteringDisplayMetadataBox)(nil)
+
+func init() {
+	BoxRegistry[SmDmBoxType] = func() Box { return &SMPTE2086MasteringDisplayMetadataBox{} }
+}
+
+func (b SMPTE2086MasteringDisplayMetadataBox) Mp4BoxType() BoxType {
+	return SmDmBoxType
+}
+
+func NewSmDmChromaticity(c Chromaticity) SmDmChromaticity {
+	return SmDmChromaticity{X: uint16(fixedPoint(c.X, 0x10000, 0xFFFF)), Y: uint16(fixedPoint(c.Y, 0x10000, 0xFFFF))}
+}
+
+func (c SmDmChromaticity) Chromaticity() Chromaticity {
+	return Chromaticity{X: float64(c.X) / 0x10000, Y: float64(c.Y) / 0x10000}
+}
+
+func NewSmDmLuminanceMax(cdPerM2 float64) SmDmLuminanceMax {
+	return SmDmLuminanceMax(fixedPoint(cdPerM2, 1<<8, 0xFFFFFFFF))
+}
+
+// CdPerM2 returns the luminance in candelas per square metre.
+func (l SmDmLuminanceMax) CdPerM2() float64 {
+	return float64(l) / (1 << 8)
+}
+
+func NewSmDmLuminanceMin(cdPerM2 float64) SmDmLuminanceMin {
+	return SmDmLuminanceMin(fixedPoint(cdPerM2, 1<<14, 0xFFFFFFFF))
+}
+
+// CdPerM2 returns the luminance in candelas per square metre.
+func (l SmDmLuminanceMin) CdPerM2() float64 {
+	return float64(l) / (1 << 14)
+}
+
+// MasteringDisplayColourVolume converts the box into the equivalent
+// MasteringDisplayColourVolumeBox.
+func (b *SMPTE2086MasteringDisplayMetadataBox) MasteringDisplayColourVolume() *MasteringDisplayColourVolumeBox {
+	mdcv := &MasteringDisplayColourVolumeBox{
+		WhitePoint:                   NewMDCVChromaticity(b.WhitePoint.Chromaticity()),
+		MaxDisplayMasteringLuminance: NewMDCVLuminance(b.LuminanceMax.CdPerM2()),
+		MinDisplayMasteringLuminance: NewMDCVLuminance(b.LuminanceMin.CdPerM2()),
+	}
+	mdcv.SetPrimaries(b.PrimaryR.Chromaticity(), b.PrimaryG.Chromaticity(), b.PrimaryB.Chromaticity())
+	return mdcv
+}
+
+func (b *SMPTE2086MasteringDisplayMetadataBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 2 // unsigned int(16) primaryRChromaticity_x;
+	b.Size += 2 // unsigned int(16) primaryRChromaticity_y;
+	b.Size += 2 // unsigned int(16) primaryGChromaticity_x;
+	b.Size += 2 // unsigned int(16) primaryGChromaticity_y;
+	b.Size += 2 // unsigned int(16) primaryBChromaticity_x;
+	b.Size += 2 // unsigned int(16) primaryBChromaticity_y;
+	b.Size += 2 // unsigned int(16) whitePointChromaticity_x;
+	b.Size += 2 // unsigned int(16) whitePointChromaticity_y;
+	b.Size += 4 // unsigned int(32) luminanceMax;
+	b.Size += 4 // unsigned int(32) luminanceMin;
+	return b.Size
+}
+
+func (b *SMPTE2086MasteringDisplayMetadataBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.PrimaryR); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.PrimaryG); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.PrimaryB); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.WhitePoint); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.LuminanceMax); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.LuminanceMin); err != nil {
+		return
+	}
+	return
+}
+
+func (b *SMPTE2086MasteringDisplayMetadataBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.PrimaryR); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.PrimaryG); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.PrimaryB); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.WhitePoint); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.LuminanceMax); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.LuminanceMin); err != nil {
+		return
+	}
+	return
+}
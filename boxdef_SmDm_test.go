@@ -0,0 +1,27 @@
+package mp4
+
+import "testing"
+
+func TestSmDmFixedPointClamping(t *testing.T) {
+	if c := NewSmDmChromaticity(Chromaticity{X: 1, Y: -0.5}); c != (SmDmChromaticity{X: 0xFFFF, Y: 0}) {
+		t.Errorf("chromaticity is %+v, want {X:65535 Y:0}", c)
+	}
+	if c := NewSmDmChromaticity(Chromaticity{X: 0.3127, Y: 0.329}); c != (SmDmChromaticity{X: 20493, Y: 21561}) {
+		t.Errorf("chromaticity is %+v, want {X:20493 Y:21561}", c)
+	}
+	if l := NewSmDmLuminanceMax(1e9); l != 0xFFFFFFFF {
+		t.Errorf("maximum luminance is %d, want %d", l, uint32(0xFFFFFFFF))
+	}
+	if l := NewSmDmLuminanceMax(1000); l != 1000<<8 {
+		t.Errorf("maximum luminance is %d, want %d", l, 1000<<8)
+	}
+	if l := NewSmDmLuminanceMin(1e6); l != 0xFFFFFFFF {
+		t.Errorf("minimum luminance is %d, want %d", l, uint32(0xFFFFFFFF))
+	}
+	if l := NewSmDmLuminanceMin(-1); l != 0 {
+		t.Errorf("minimum luminance is %d, want 0", l)
+	}
+	if c := NewMDCVChromaticity(Chromaticity{X: 2, Y: 1}); c != (MDCVChromaticity{X: 0xFFFF, Y: 50000}) {
+		t.Errorf("mdcv chromaticity is %+v, want {X:65535 Y:50000}", c)
+	}
+}
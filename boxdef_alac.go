@@ -0,0 +1,158 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Apple Lossless Audio Codec ALAC Specific Info
+
+// Box Type: ‘alac’
+// Container: ALAC Sample Entry (‘alac’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The ALACSpecificBox carries the ALAC magic cookie, the ALACSpecificConfig,
+// which the decoder needs to decode the samples. The cookie may be followed by
+// an ALACChannelLayoutInfo (‘chan’) for streams with more than two channels,
+// which is kept as is in UnknownData.
+//
+// The box shares its type with the sample entry containing it, so it is only
+// recognized as a child of an AudioSampleEntryBox.
+type ALACSpecificBox struct {
+	FullHeader
+	NullContainer
+
+	// is the frames per packet when no explicit frames per packet setting is
+	// present in the packet header. The encoder frames per packet can be
+	// explicitly set but for maximum compatibility, the default encoder
+	// setting of 4096 should be used.
+	FrameLength uint32
+
+	// indicates the compatible version of the ALAC decoder, currently 0.
+	CompatibleVersion uint8
+
+	// describes the bit depth of the source PCM data.
+	BitDepth uint8
+
+	// Tuning parameters, currently 40, 10 and 14.
+	PB uint8
+	MB uint8
+	KB uint8
+
+	NumChannels uint8
+
+	// is currently unused and should be set to 255.
+	MaxRun uint16
+
+	// the maximum size of an ALAC packet within the encoded stream, or 0 if
+	// unknown.
+	MaxFrameBytes uint32
+
+	// the average bit rate in bits per second of the ALAC stream, or 0 if
+	// unknown.
+	AvgBitRate uint32
+
+	SampleRate uint32
+
+	// holds any data following the ALACSpecificConfig.
+	UnknownData []byte
+}
+
+var _ Box = (*ALACSpecificBox)(nil)
+
+func (b ALACSpecificBox) Mp4BoxType() BoxType {
+	return AlacBoxType
+}
+
+func (b *ALACSpecificBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 4 // uint32_t frameLength;
+	b.Size += 1 // uint8_t compatibleVersion;
+	b.Size += 1 // uint8_t bitDepth;
+	b.Size += 1 // uint8_t pb;
+	b.Size += 1 // uint8_t mb;
+	b.Size += 1 // uint8_t kb;
+	b.Size += 1 // uint8_t numChannels;
+	b.Size += 2 // uint16_t maxRun;
+	b.Size += 4 // uint32_t maxFrameBytes;
+	b.Size += 4 // uint32_t avgBitRate;
+	b.Size += 4 // uint32_t sampleRate;
+	b.Size += uint32(len(b.UnknownData))
+	return b.Size
+}
+
+func (b *ALACSpecificBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.FrameLength); err != nil {
+		return
+	}
+	var tmp [6]uint8
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	b.CompatibleVersion = tmp[0]
+	b.BitDepth = tmp[1]
+	b.PB = tmp[2]
+	b.MB = tmp[3]
+	b.KB = tmp[4]
+	b.NumChannels = tmp[5]
+	if err = binary.Read(r, binary.BigEndian, &b.MaxRun); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MaxFrameBytes); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.AvgBitRate); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.SampleRate); err != nil {
+		return
+	}
+	b.UnknownData = nil
+	used := b.headerSize() + 24
+	if used > b.Size {
+		err = fmt.Errorf("alac box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	if used < b.Size {
+		b.UnknownData = make([]byte, b.Size-used)
+		if _, err = io.ReadFull(r, b.UnknownData); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *ALACSpecificBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.FrameLength); err != nil {
+		return
+	}
+	tmp := [6]uint8{b.CompatibleVersion, b.BitDepth, b.PB, b.MB, b.KB, b.NumChannels}
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxRun); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxFrameBytes); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.AvgBitRate); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.SampleRate); err != nil {
+		return
+	}
+	if _, err = w.Write(b.UnknownData); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,268 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// 12.2.3 Audio Sample entry
+
+// Audio tracks use AudioSampleEntryBox.
+//
+// The samplerate, samplesize and channelcount fields document the default
+// audio output playback format for this media. The timescale for an audio
+// track should be chosen to match the sampling rate, or be an integer multiple
+// of it, to enable sample‐accurate timing.
+//
+// The first 8 reserved bytes of the ISO sample entry are used by QuickTime as
+// the sound description version, revision level and vendor. Version 1 sound
+// descriptions append four 32‐bit fields describing the packet layout, and
+// version 2 sound descriptions replace the 16.16 sample rate and 16‐bit channel
+// count with a 64‐bit floating point sample rate and a 32‐bit channel count. In
+// ISO files SoundVersion is always 0.
+type AudioSampleEntryBox struct {
+	SampleEntry
+
+	// QuickTime sound description version, revision level and vendor. Zero
+	// for ISO audio sample entries.
+	SoundVersion uint16
+	Revision     uint16
+	Vendor       uint32
+
+	ChannelCount  uint16
+	SampleSize    uint16
+	CompressionID int16
+	PacketSize    uint16
+
+	// is a fixed 16.16 number giving the sampling rate.
+	SampleRate uint32
+
+	// QuickTime sound description version 1 fields.
+	SamplesPerPacket uint32
+	BytesPerPacket   uint32
+	BytesPerFrame    uint32
+	BytesPerSample   uint32
+
+	// QuickTime sound description version 2 fields.
+	SizeOfStructOnly              uint32
+	AudioSampleRate               float64
+	NumAudioChannels              uint32
+	ConstBitsPerChannel           uint32
+	FormatSpecificFlags           uint32
+	ConstBytesPerAudioPacket      uint32
+	ConstLPCMFramesPerAudioPacket uint32
+}
+
+var _ Box = (*AudioSampleEntryBox)(nil)
+
+func init() {
+	BoxRegistry[Mp4aBoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[EncaBoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[Ac3BoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[Ec3BoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[Ac4BoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[OpusBoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[FLaCBoxType] = func() Box { return &AudioSampleEntryBox{} }
+	BoxRegistry[AlacBoxType] = func() Box { return &AudioSampleEntryBox{} }
+}
+
+// SampleRateHz returns the sampling rate of the entry in Hz.
+func (b *AudioSampleEntryBox) SampleRateHz() float64 {
+	if b.SoundVersion == 2 {
+		return b.AudioSampleRate
+	}
+	return float64(b.SampleRate) / 0x10000
+}
+
+// Channels returns the number of audio channels of the entry.
+func (b *AudioSampleEntryBox) Channels() uint32 {
+	if b.SoundVersion == 2 {
+		return b.NumAudioChannels
+	}
+	return uint32(b.ChannelCount)
+}
+
+func (b *AudioSampleEntryBox) AudioSampleEntrySize() (size uint32) {
+	size = b.SampleEntrySize()
+	size += 2 // unsigned int(16) version; // const unsigned int(32)[2] reserved = 0;
+	size += 2 // unsigned int(16) revision_level;
+	size += 4 // unsigned int(32) vendor;
+	size += 2 // template unsigned int(16) channelcount = 2;
+	size += 2 // template unsigned int(16) samplesize = 16;
+	size += 2 // unsigned int(16) pre_defined = 0;
+	size += 2 // const unsigned int(16) reserved = 0;
+	size += 4 // template unsigned int(32) samplerate = { default samplerate of media}<<16;
+	if b.SoundVersion == 1 {
+		size += 4 // unsigned int(32) samples_per_packet;
+		size += 4 // unsigned int(32) bytes_per_packet;
+		size += 4 // unsigned int(32) bytes_per_frame;
+		size += 4 // unsigned int(32) bytes_per_sample;
+	} else if b.SoundVersion == 2 {
+		size += 4 // unsigned int(32) size_of_struct_only;
+		size += 8 // float(64) audio_sample_rate;
+		size += 4 // unsigned int(32) num_audio_channels;
+		size += 4 // const unsigned int(32) always_7F000000 = 0x7F000000;
+		size += 4 // unsigned int(32) const_bits_per_channel;
+		size += 4 // unsigned int(32) format_specific_flags;
+		size += 4 // unsigned int(32) const_bytes_per_audio_packet;
+		size += 4 // unsigned int(32) const_LPCM_frames_per_audio_packet;
+	}
+	return
+}
+
+func (b *AudioSampleEntryBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.AudioSampleEntrySize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *AudioSampleEntryBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.SampleEntry.Mp4BoxRead(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.SoundVersion); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Revision); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Vendor); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.ChannelCount); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.SampleSize); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.CompressionID); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.PacketSize); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.SampleRate); err != nil {
+		return
+	}
+	if b.SoundVersion == 1 {
+		var tmp [4]uint32
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+			return
+		}
+		b.SamplesPerPacket = tmp[0]
+		b.BytesPerPacket = tmp[1]
+		b.BytesPerFrame = tmp[2]
+		b.BytesPerSample = tmp[3]
+	} else if b.SoundVersion == 2 {
+		if err = binary.Read(r, binary.BigEndian, &b.SizeOfStructOnly); err != nil {
+			return
+		}
+		var rate uint64
+		if err = binary.Read(r, binary.BigEndian, &rate); err != nil {
+			return
+		}
+		b.AudioSampleRate = math.Float64frombits(rate)
+		var tmp [6]uint32
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+			return
+		}
+		b.NumAudioChannels = tmp[0]
+		b.ConstBitsPerChannel = tmp[2]
+		b.FormatSpecificFlags = tmp[3]
+		b.ConstBytesPerAudioPacket = tmp[4]
+		b.ConstLPCMFramesPerAudioPacket = tmp[5]
+	}
+	if err = b.readChildren(r, b.Size-b.AudioSampleEntrySize()); err != nil {
+		return
+	}
+	return
+}
+
+// readChildren reads the child boxes like Mp4BoxReadChildren, except that an
+// ‘alac’ child is read as the ALACSpecificBox rather than as a sample entry.
+func (b *AudioSampleEntryBox) readChildren(r io.Reader, size uint32) (err error) {
+	remainingSize := int64(size)
+	for remainingSize > 0 {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			return
+		}
+		var child Box
+		if header.Type == AlacBoxType {
+			child = &ALACSpecificBox{}
+			if err = child.Mp4BoxRead(r, header); err != nil {
+				return
+			}
+		} else if child, err = ReadBoxAfterHeader(r, header); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *AudioSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.SampleEntry.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.SoundVersion); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Revision); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Vendor); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.ChannelCount); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.SampleSize); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.CompressionID); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.PacketSize); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.SampleRate); err != nil {
+		return
+	}
+	if b.SoundVersion == 1 {
+		tmp := [4]uint32{b.SamplesPerPacket, b.BytesPerPacket, b.BytesPerFrame, b.BytesPerSample}
+		if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+			return
+		}
+	} else if b.SoundVersion == 2 {
+		if err = binary.Write(w, binary.BigEndian, b.SizeOfStructOnly); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, math.Float64bits(b.AudioSampleRate)); err != nil {
+			return
+		}
+		tmp := [6]uint32{
+			b.NumAudioChannels,
+			0x7F000000,
+			b.ConstBitsPerChannel,
+			b.FormatSpecificFlags,
+			b.ConstBytesPerAudioPacket,
+			b.ConstLPCMFramesPerAudioPacket,
+		}
+		if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+			return
+		}
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,211 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// AV1 Codec ISO Media File Format Binding 2.3 AV1 Codec Configuration Box
+
+// Box Type: ‘av1C’
+// Container: AV1 Sample Entry (‘av01’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The AV1CodecConfigurationBox contains the AV1CodecConfigurationRecord. Its
+// fields mirror those of the Sequence Header OBU, which is also contained in
+// ConfigOBUs together with any Metadata OBUs that apply to all samples.
+type AV1CodecConfigurationBox struct {
+	Header
+	NullContainer
+
+	// shall be set to 1.
+	Version uint8
+
+	SeqProfile   uint8
+	SeqLevelIdx0 uint8
+	SeqTier0     uint8
+	HighBitdepth bool
+	TwelveBit    bool
+	Monochrome   bool
+
+	ChromaSubsamplingX   bool
+	ChromaSubsamplingY   bool
+	ChromaSamplePosition uint8
+
+	InitialPresentationDelayPresent bool
+
+	// is the number of decoded frames minus one that should be buffered
+	// before starting playback, if InitialPresentationDelayPresent is set.
+	InitialPresentationDelayMinusOne uint8
+
+	// contains zero or more OBUs in low overhead bitstream format, each with
+	// obu_has_size_field set to 1.
+	ConfigOBUs []byte
+}
+
+var _ Box = (*AV1CodecConfigurationBox)(nil)
+
+func init() {
+	BoxRegistry[Av1CBoxType] = func() Box { return &AV1CodecConfigurationBox{} }
+}
+
+func (b AV1CodecConfigurationBox) Mp4BoxType() BoxType {
+	return Av1CBoxType
+}
+
+// BitDepth returns the bit depth signalled by HighBitdepth and TwelveBit.
+func (b *AV1CodecConfigurationBox) BitDepth() uint8 {
+	if !b.HighBitdepth {
+		return 8
+	}
+	if b.TwelveBit {
+		return 12
+	}
+	return 10
+}
+
+// CodecString returns the RFC 6381 codecs parameter of the stream, e.g.
+// ‘av01.0.04M.08’. The optional colour fields are not included, as they are
+// carried by the sequence header and the colour information box.
+func (b *AV1CodecConfigurationBox) CodecString() string {
+	tier := 'M'
+	if b.SeqTier0 != 0 {
+		tier = 'H'
+	}
+	return fmt.Sprintf("av01.%d.%02d%c.%02d", b.SeqProfile, b.SeqLevelIdx0, tier, b.BitDepth())
+}
+
+// ConfigOBUList splits ConfigOBUs into the individual OBUs, each including
+// its header and size field.
+func (b *AV1CodecConfigurationBox) ConfigOBUList() (obus [][]byte, err error) {
+	data := b.ConfigOBUs
+	for len(data) > 0 {
+		headerSize := 1
+		if data[0]&0x04 != 0 { // obu_extension_flag
+			headerSize++
+		}
+		if data[0]&0x02 == 0 || len(data) < headerSize { // obu_has_size_field
+			err = fmt.Errorf("av1C config OBU without size field: %w", ErrInvalidFormat)
+			return
+		}
+		var obuSize uint64
+		i := headerSize
+		for j := 0; ; j++ {
+			if i >= len(data) || j == 8 {
+				err = fmt.Errorf("av1C config OBU has invalid size field: %w", ErrInvalidFormat)
+				return
+			}
+			obuSize |= uint64(data[i]&0x7F) << (7 * j)
+			i++
+			if data[i-1]&0x80 == 0 {
+				break
+			}
+		}
+		if obuSize > uint64(len(data)-i) {
+			err = fmt.Errorf("av1C config OBU exceeds box boundary: %w", ErrInvalidFormat)
+			return
+		}
+		end := i + int(obuSize)
+		obus = append(obus, data[:end])
+		data = data[end:]
+	}
+	return
+}
+
+func (b *AV1CodecConfigurationBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	// unsigned int(1) marker = 1;
+	// unsigned int(7) version = 1;
+	// unsigned int(3) seq_profile;
+	// unsigned int(5) seq_level_idx_0;
+	// unsigned int(1) seq_tier_0;
+	// unsigned int(1) high_bitdepth;
+	// unsigned int(1) twelve_bit;
+	// unsigned int(1) monochrome;
+	// unsigned int(1) chroma_subsampling_x;
+	// unsigned int(1) chroma_subsampling_y;
+	// unsigned int(2) chroma_sample_position;
+	// unsigned int(3) reserved = 0;
+	// unsigned int(1) initial_presentation_delay_present;
+	// unsigned int(4) initial_presentation_delay_minus_one or reserved = 0;
+	b.Size += 4
+	b.Size += uint32(len(b.ConfigOBUs)) // unsigned int(8) configOBUs[];
+	return b.Size
+}
+
+func (b *AV1CodecConfigurationBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize()+4 {
+		err = fmt.Errorf("av1C box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	var tmp [4]byte
+	if _, err = io.ReadFull(r, tmp[:]); err != nil {
+		return
+	}
+	br := newBitReader(tmp[:])
+	if !br.readFlag() {
+		err = fmt.Errorf("av1C marker bit not set: %w", ErrInvalidFormat)
+		return
+	}
+	b.Version = uint8(br.read(7))
+	b.SeqProfile = uint8(br.read(3))
+	b.SeqLevelIdx0 = uint8(br.read(5))
+	b.SeqTier0 = uint8(br.read(1))
+	b.HighBitdepth = br.readFlag()
+	b.TwelveBit = br.readFlag()
+	b.Monochrome = br.readFlag()
+	b.ChromaSubsamplingX = br.readFlag()
+	b.ChromaSubsamplingY = br.readFlag()
+	b.ChromaSamplePosition = uint8(br.read(2))
+	br.skip(3) // reserved
+	b.InitialPresentationDelayPresent = br.readFlag()
+	b.InitialPresentationDelayMinusOne = 0
+	if b.InitialPresentationDelayPresent {
+		b.InitialPresentationDelayMinusOne = uint8(br.read(4))
+	}
+	b.ConfigOBUs = nil
+	if size := b.Size - b.HeaderSize() - 4; size > 0 {
+		b.ConfigOBUs = make([]byte, size)
+		if _, err = io.ReadFull(r, b.ConfigOBUs); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *AV1CodecConfigurationBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	var bw bitWriter
+	bw.writeFlag(true) // marker
+	bw.write(uint64(b.Version), 7)
+	bw.write(uint64(b.SeqProfile), 3)
+	bw.write(uint64(b.SeqLevelIdx0), 5)
+	bw.write(uint64(b.SeqTier0), 1)
+	bw.writeFlag(b.HighBitdepth)
+	bw.writeFlag(b.TwelveBit)
+	bw.writeFlag(b.Monochrome)
+	bw.writeFlag(b.ChromaSubsamplingX)
+	bw.writeFlag(b.ChromaSubsamplingY)
+	bw.write(uint64(b.ChromaSamplePosition), 2)
+	bw.write(0, 3) // reserved
+	bw.writeFlag(b.InitialPresentationDelayPresent)
+	if b.InitialPresentationDelayPresent {
+		bw.write(uint64(b.InitialPresentationDelayMinusOne), 4)
+	} else {
+		bw.write(0, 4) // reserved
+	}
+	if _, err = w.Write(bw.bytes()); err != nil {
+		return
+	}
+	if _, err = w.Write(b.ConfigOBUs); err != nil {
+		return
+	}
+	return
+}
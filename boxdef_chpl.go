@@ -0,0 +1,126 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Nero Chapter List
+
+// Box Type: ‘chpl’
+// Container: User Data Box (‘udta’) of the Movie Box (‘moov’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The ChapterListBox holds the chapter markers of the presentation in the
+// format introduced by Nero. Version 1 of the box has 4 reserved bytes before
+// the chapter count.
+type ChapterListBox struct {
+	FullHeader
+	NullContainer
+
+	Reserved uint32
+
+	Chapters []ChapterListEntry
+}
+
+type ChapterListEntry struct {
+	// is the start time of the chapter in units of 100 nanoseconds.
+	StartTime uint64
+
+	// is the UTF‐8 title of the chapter of at most 255 bytes.
+	Title string
+}
+
+var _ Box = (*ChapterListBox)(nil)
+
+func init() {
+	BoxRegistry[ChplBoxType] = func() Box { return &ChapterListBox{} }
+}
+
+func (b ChapterListBox) Mp4BoxType() BoxType {
+	return ChplBoxType
+}
+
+func (b *ChapterListBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	if b.Version == 1 {
+		b.Size += 4 // unsigned int(32) reserved;
+	}
+	b.Size += 1 // unsigned int(8) chapter_count;
+	for _, chapter := range b.Chapters {
+		b.Size += 8                          // unsigned int(64) start_time;
+		b.Size += 1                          // unsigned int(8) title_length;
+		b.Size += uint32(len(chapter.Title)) // utf8 title[title_length];
+	}
+	return b.Size
+}
+
+func (b *ChapterListBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Read(r, binary.BigEndian, &b.Reserved); err != nil {
+			return
+		}
+	}
+	var chapterCount uint8
+	if err = binary.Read(r, binary.BigEndian, &chapterCount); err != nil {
+		return
+	}
+	b.Chapters = make([]ChapterListEntry, chapterCount)
+	for i := range b.Chapters {
+		if err = binary.Read(r, binary.BigEndian, &b.Chapters[i].StartTime); err != nil {
+			return
+		}
+		var titleLength uint8
+		if err = binary.Read(r, binary.BigEndian, &titleLength); err != nil {
+			return
+		}
+		title := make([]byte, titleLength)
+		if _, err = io.ReadFull(r, title); err != nil {
+			return
+		}
+		b.Chapters[i].Title = string(title)
+	}
+	return
+}
+
+func (b *ChapterListBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if len(b.Chapters) > 0xFF {
+		err = fmt.Errorf("chpl box cannot have more than 255 chapters: %w", ErrInvalidFormat)
+		return
+	}
+	for _, chapter := range b.Chapters {
+		if len(chapter.Title) > 0xFF {
+			err = fmt.Errorf("chpl chapter title longer than 255 bytes: %w", ErrInvalidFormat)
+			return
+		}
+	}
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Write(w, binary.BigEndian, b.Reserved); err != nil {
+			return
+		}
+	}
+	if err = binary.Write(w, binary.BigEndian, uint8(len(b.Chapters))); err != nil {
+		return
+	}
+	for _, chapter := range b.Chapters {
+		if err = binary.Write(w, binary.BigEndian, chapter.StartTime); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, uint8(len(chapter.Title))); err != nil {
+			return
+		}
+		if _, err = io.WriteString(w, chapter.Title); err != nil {
+			return
+		}
+	}
+	return
+}
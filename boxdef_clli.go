@@ -0,0 +1,71 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ISO/IEC 23001‐8 / ISO/IEC 14496‐12 12.1.6 Content light level
+
+// Box Type: ‘clli’
+// Container: Visual Sample Entry
+// Mandatory: No
+// Quantity: Zero or one
+
+// The ContentLightLevelBox carries the upper bounds of the light level of the
+// content, with the semantics of the content light level information SEI
+// message of H.265.
+type ContentLightLevelBox struct {
+	Header
+	NullContainer
+
+	// is the maximum content light level (MaxCLL) in cd/m².
+	MaxContentLightLevel uint16
+
+	// is the maximum frame average light level (MaxFALL) in cd/m².
+	MaxPicAverageLightLevel uint16
+}
+
+var _ Box = (*ContentLightLevelBox)(nil)
+
+func init() {
+	BoxRegistry[ClliBoxType] = func() Box { return &ContentLightLevelBox{} }
+}
+
+func (b ContentLightLevelBox) Mp4BoxType() BoxType {
+	return ClliBoxType
+}
+
+func (b *ContentLightLevelBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 2 // unsigned int(16) max_content_light_level;
+	b.Size += 2 // unsigned int(16) max_pic_average_light_level;
+	return b.Size
+}
+
+func (b *ContentLightLevelBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MaxContentLightLevel); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MaxPicAverageLightLevel); err != nil {
+		return
+	}
+	return
+}
+
+func (b *ContentLightLevelBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxContentLightLevel); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxPicAverageLightLevel); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.7.5 Chunk Offset Box
+
+// Box Type: ‘co64’
+// Container: Sample Table Box (‘stbl’)
+// Mandatory: Yes
+// Quantity: Exactly one variant must be present
+
+// The ChunkLargeOffsetBox is the 64‐bit variant of the ChunkOffsetBox, used
+// when a chunk offset does not fit into 32 bits.
+type ChunkLargeOffsetBox struct {
+	FullHeader
+	NullContainer
+	Entries []ChunkLargeOffsetEntry
+}
+
+var _ Box = (*ChunkLargeOffsetBox)(nil)
+
+func init() {
+	BoxRegistry[Co64BoxType] = func() Box { return &ChunkLargeOffsetBox{} }
+}
+
+type ChunkLargeOffsetEntry struct {
+	// is a 64 bit integer that gives the offset of the start of a chunk into
+	// its containing media file.
+	ChunkOffset uint64
+}
+
+func (b ChunkLargeOffsetBox) Mp4BoxType() BoxType {
+	return Co64BoxType
+}
+
+func (b *ChunkLargeOffsetBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 4 // unsigned int(32) entry_count;
+	// for (i=0; i < entry_count; i++) {
+	//     unsigned int(64) chunk_offset;
+	// }
+	b.Size += 8 * uint32(len(b.Entries))
+	return b.Size
+}
+
+func (b *ChunkLargeOffsetBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	var entryCount uint32
+	if err = binary.Read(r, binary.BigEndian, &entryCount); err != nil {
+		return
+	}
+	b.Entries = make([]ChunkLargeOffsetEntry, entryCount)
+	if err = binary.Read(r, binary.BigEndian, b.Entries); err != nil {
+		return
+	}
+	return
+}
+
+func (b *ChunkLargeOffsetBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(len(b.Entries))); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Entries); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 Cue Time Box
+
+// Box Types: ‘ctim’
+// Container: WebVTT Cue Box (‘vttc’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The CueTimeBox contains the current time of a cue split into several
+// samples, as a WebVTT timestamp relative to the start of the cue. It is
+// used when the cue payload contains timestamps.
+type CueTimeBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	CueCurrentTime string
+}
+
+var _ Box = (*CueTimeBox)(nil)
+
+func init() {
+	BoxRegistry[CtimBoxType] = func() Box { return &CueTimeBox{} }
+}
+
+func (b CueTimeBox) Mp4BoxType() BoxType {
+	return CtimBoxType
+}
+
+func (b *CueTimeBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.CueCurrentTime)) // boxstring cue_current_time;
+	return b.Size
+}
+
+func (b *CueTimeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.CueCurrentTime, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *CueTimeBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.CueCurrentTime); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,154 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Encapsulation of Opus in ISO Base Media File Format 4.3.2 Opus Specific Box
+
+// Box Type: ‘dOps’
+// Container: Opus Sample Entry (‘Opus’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The OpusSpecificBox contains the Version field and the Opus identification
+// header of the Ogg encapsulation without its magic signature, stored in big
+// endian byte order. The channel mapping table is present if
+// ChannelMappingFamily is not 0.
+type OpusSpecificBox struct {
+	Header
+	NullContainer
+
+	// shall be set to 0.
+	Version uint8
+
+	// specifies the number of output channels.
+	OutputChannelCount uint8
+
+	// indicates the number of samples at 48000 Hz to discard from the decoder
+	// output when starting playback.
+	PreSkip uint16
+
+	// is the sample rate of the original input, for information only.
+	InputSampleRate uint32
+
+	// is a Q7.8 gain in dB to apply to the decoder output.
+	OutputGain int16
+
+	// indicates the order and semantic meaning of the output channels.
+	ChannelMappingFamily uint8
+
+	// ChannelMappingTable
+	StreamCount    uint8
+	CoupledCount   uint8
+	ChannelMapping []uint8
+}
+
+var _ Box = (*OpusSpecificBox)(nil)
+
+func init() {
+	BoxRegistry[DOpsBoxType] = func() Box { return &OpusSpecificBox{} }
+}
+
+func (b OpusSpecificBox) Mp4BoxType() BoxType {
+	return DOpsBoxType
+}
+
+func (b *OpusSpecificBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 1 // unsigned int(8) Version;
+	b.Size += 1 // unsigned int(8) OutputChannelCount;
+	b.Size += 2 // unsigned int(16) PreSkip;
+	b.Size += 4 // unsigned int(32) InputSampleRate;
+	b.Size += 2 // signed int(16) OutputGain;
+	b.Size += 1 // unsigned int(8) ChannelMappingFamily;
+	if b.ChannelMappingFamily != 0 {
+		b.Size += 1 // unsigned int(8) StreamCount;
+		b.Size += 1 // unsigned int(8) CoupledCount;
+		b.Size += uint32(len(b.ChannelMapping))
+	}
+	return b.Size
+}
+
+func (b *OpusSpecificBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Version); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.OutputChannelCount); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.PreSkip); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.InputSampleRate); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.OutputGain); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.ChannelMappingFamily); err != nil {
+		return
+	}
+	b.StreamCount = 0
+	b.CoupledCount = 0
+	b.ChannelMapping = nil
+	if b.ChannelMappingFamily != 0 {
+		if err = binary.Read(r, binary.BigEndian, &b.StreamCount); err != nil {
+			return
+		}
+		if err = binary.Read(r, binary.BigEndian, &b.CoupledCount); err != nil {
+			return
+		}
+		b.ChannelMapping = make([]uint8, b.OutputChannelCount)
+		if _, err = io.ReadFull(r, b.ChannelMapping); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *OpusSpecificBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if b.ChannelMappingFamily != 0 && len(b.ChannelMapping) != int(b.OutputChannelCount) {
+		err = fmt.Errorf("dOps channel mapping has %d entries for %d output channels: %w", len(b.ChannelMapping), b.OutputChannelCount, ErrInvalidFormat)
+		return
+	}
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Version); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.OutputChannelCount); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.PreSkip); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.InputSampleRate); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.OutputGain); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.ChannelMappingFamily); err != nil {
+		return
+	}
+	if b.ChannelMappingFamily != 0 {
+		if err = binary.Write(w, binary.BigEndian, b.StreamCount); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, b.CoupledCount); err != nil {
+			return
+		}
+		if _, err = w.Write(b.ChannelMapping); err != nil {
+			return
+		}
+	}
+	return
+}
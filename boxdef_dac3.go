@@ -0,0 +1,194 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// ETSI TS 102 366 F.4 AC3SpecificBox
+
+// Box Type: ‘dac3’
+// Container: AC3SampleEntry (‘ac-3’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The AC3SpecificBox carries the fields of the AC‐3 bit stream information
+// needed to configure a decoder without parsing the first sync frame. The
+// channelcount and samplerate fields of the sample entry are not used by AC‐3
+// and are set to 2 and the sampling frequency respectively.
+type AC3SpecificBox struct {
+	Header
+	NullContainer
+
+	// has the same meaning and is set to the same value as the fscod field in
+	// the AC‐3 bit stream.
+	Fscod AC3SampleRateCode
+
+	// has the same meaning and is set to the same value as the bsid field in
+	// the AC‐3 bit stream.
+	Bsid uint8
+
+	// has the same meaning and is set to the same value as the bsmod field in
+	// the AC‐3 bit stream.
+	Bsmod AC3BitStreamMode
+
+	// has the same meaning and is set to the same value as the acmod field in
+	// the AC‐3 bit stream.
+	Acmod AC3AudioCodingMode
+
+	// has the same meaning and is set to the same value as the lfeon field in
+	// the AC‐3 bit stream.
+	Lfeon bool
+
+	// indicates the data rate of the AC‐3 bit stream in kbit/s, as the
+	// frmsizecod field of the AC‐3 bit stream divided by 2.
+	BitRateCode uint8
+}
+
+var _ Box = (*AC3SpecificBox)(nil)
+
+func init() {
+	BoxRegistry[Dac3BoxType] = func() Box { return &AC3SpecificBox{} }
+}
+
+func (b AC3SpecificBox) Mp4BoxType() BoxType {
+	return Dac3BoxType
+}
+
+// ChannelCount returns the number of output channels including the LFE
+// channel.
+func (b *AC3SpecificBox) ChannelCount() uint8 {
+	count := b.Acmod.ChannelCount()
+	if b.Lfeon {
+		count++
+	}
+	return count
+}
+
+// BitRate returns the nominal bit rate in bit/s, or 0 if the bit rate code is
+// reserved.
+func (b *AC3SpecificBox) BitRate() uint32 {
+	if int(b.BitRateCode) < len(ac3BitRates) {
+		return ac3BitRates[b.BitRateCode] * 1000
+	}
+	return 0
+}
+
+func (b *AC3SpecificBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	// bit(2) fscod;
+	// bit(5) bsid;
+	// bit(3) bsmod;
+	// bit(3) acmod;
+	// bit(1) lfeon;
+	// bit(5) bit_rate_code;
+	// bit(5) reserved = 0;
+	b.Size += 3
+	return b.Size
+}
+
+func (b *AC3SpecificBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size != b.HeaderSize()+3 {
+		err = fmt.Errorf("dac3 box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	var tmp [3]byte
+	if _, err = io.ReadFull(r, tmp[:]); err != nil {
+		return
+	}
+	br := newBitReader(tmp[:])
+	b.Fscod = AC3SampleRateCode(br.read(2))
+	b.Bsid = uint8(br.read(5))
+	b.Bsmod = AC3BitStreamMode(br.read(3))
+	b.Acmod = AC3AudioCodingMode(br.read(3))
+	b.Lfeon = br.readFlag()
+	b.BitRateCode = uint8(br.read(5))
+	return
+}
+
+func (b *AC3SpecificBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	var bw bitWriter
+	bw.write(uint64(b.Fscod), 2)
+	bw.write(uint64(b.Bsid), 5)
+	bw.write(uint64(b.Bsmod), 3)
+	bw.write(uint64(b.Acmod), 3)
+	bw.writeFlag(b.Lfeon)
+	bw.write(uint64(b.BitRateCode), 5)
+	bw.write(0, 5) // reserved
+	if _, err = w.Write(bw.bytes()); err != nil {
+		return
+	}
+	return
+}
+
+// ETSI TS 102 366 4.4.1.3 fscod ‐ Sample rate code
+type AC3SampleRateCode uint8
+
+const (
+	AC3SampleRate48000 AC3SampleRateCode = 0
+	AC3SampleRate44100 AC3SampleRateCode = 1
+	AC3SampleRate32000 AC3SampleRateCode = 2
+
+	// In E‐AC‐3 this value signals that the reduced sample rate given by
+	// fscod2 is used.
+	AC3SampleRateReduced AC3SampleRateCode = 3
+)
+
+// SampleRate returns the sampling frequency in Hz, or 0 for the reduced
+// sample rate code.
+func (c AC3SampleRateCode) SampleRate() uint32 {
+	switch c {
+	case AC3SampleRate48000:
+		return 48000
+	case AC3SampleRate44100:
+		return 44100
+	case AC3SampleRate32000:
+		return 32000
+	}
+	return 0
+}
+
+// ETSI TS 102 366 4.4.2.2 bsmod ‐ Bit stream mode
+type AC3BitStreamMode uint8
+
+const (
+	AC3BitStreamModeCompleteMain     AC3BitStreamMode = 0
+	AC3BitStreamModeMusicAndEffects  AC3BitStreamMode = 1
+	AC3BitStreamModeVisuallyImpaired AC3BitStreamMode = 2
+	AC3BitStreamModeHearingImpaired  AC3BitStreamMode = 3
+	AC3BitStreamModeDialogue         AC3BitStreamMode = 4
+	AC3BitStreamModeCommentary       AC3BitStreamMode = 5
+	AC3BitStreamModeEmergency        AC3BitStreamMode = 6
+	AC3BitStreamModeVoiceOver        AC3BitStreamMode = 7
+)
+
+// ETSI TS 102 366 4.4.2.3 acmod ‐ Audio coding mode
+type AC3AudioCodingMode uint8
+
+const (
+	AC3AudioCodingModeDualMono AC3AudioCodingMode = 0 // 1+1, Ch1, Ch2
+	AC3AudioCodingMode1_0      AC3AudioCodingMode = 1 // C
+	AC3AudioCodingMode2_0      AC3AudioCodingMode = 2 // L, R
+	AC3AudioCodingMode3_0      AC3AudioCodingMode = 3 // L, C, R
+	AC3AudioCodingMode2_1      AC3AudioCodingMode = 4 // L, R, S
+	AC3AudioCodingMode3_1      AC3AudioCodingMode = 5 // L, C, R, S
+	AC3AudioCodingMode2_2      AC3AudioCodingMode = 6 // L, R, SL, SR
+	AC3AudioCodingMode3_2      AC3AudioCodingMode = 7 // L, C, R, SL, SR
+)
+
+// ChannelCount returns the number of full bandwidth channels of the audio
+// coding mode.
+func (m AC3AudioCodingMode) ChannelCount() uint8 {
+	return [...]uint8{2, 1, 2, 3, 3, 4, 4, 5}[m&7]
+}
+
+var ac3BitRates = [...]uint32{
+	32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 448, 512, 576, 640,
+}
@@ -0,0 +1,313 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// ETSI TS 103 190‐2 E.5 AC4SpecificBox
+
+// Box Type: ‘dac4’
+// Container: AC4SampleEntry (‘ac‐4’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The AC4SpecificBox carries the ac4_dsi_v1 decoder specific information,
+// which describes the bit stream and lists the presentations it contains.
+type AC4SpecificBox struct {
+	Header
+	NullContainer
+
+	// shall be set to 1.
+	AC4DSIVersion uint8
+
+	// indicates the version of the AC‐4 bit stream.
+	BitstreamVersion uint8
+
+	// indicates the sampling frequency of the bit stream, 0 for 44.1 kHz and
+	// 1 for 48 kHz.
+	FsIndex uint8
+
+	// indicates the frame rate of the bit stream, interpreted together with
+	// FsIndex.
+	FrameRateIndex uint8
+
+	// present if BitstreamVersion is larger than 1.
+	BProgramID     bool
+	ShortProgramID uint16
+	BUUID          bool
+	ProgramUUID    [16]byte
+
+	// ac4_bitrate_dsi()
+	BitRateMode      uint8
+	BitRate          uint32
+	BitRatePrecision uint32
+
+	Presentations []AC4PresentationDSI
+
+	// holds any data following the presentations.
+	UnknownData []byte
+}
+
+// AC4PresentationDSI is one entry of the presentation list. The presentation
+// is kept as the raw ac4_presentation_v0_dsi or ac4_presentation_v1_dsi
+// payload in Data, which is written back unchanged. The leading fields of the
+// payload, which identify the presentation and its channel configuration, are
+// decoded into the remaining fields when the box is read.
+type AC4PresentationDSI struct {
+	PresentationVersion uint8
+
+	// is the presentation payload of pres_bytes bytes.
+	Data []byte
+
+	// presentation_config or presentation_config_v1.
+	PresentationConfig uint8
+
+	MDCompat uint8
+
+	BPresentationID bool
+	PresentationID  uint8
+
+	DSIFrameRateMultiplyInfo uint8
+	DSIFrameRateFractionInfo uint8
+	PresentationEMDFVersion  uint8
+	PresentationKeyID        uint16
+
+	// indicates that DSIPresentationChMode and PresentationChannelMask are
+	// present. Always set for presentation version 0.
+	BPresentationChannelCoded bool
+
+	DSIPresentationChMode        uint8
+	PresBFourBackChannelsPresent bool
+	PresTopChannelPairs          uint8
+
+	// presentation_channel_mask or presentation_channel_mask_v1.
+	PresentationChannelMask uint32
+}
+
+var _ Box = (*AC4SpecificBox)(nil)
+
+func init() {
+	BoxRegistry[Dac4BoxType] = func() Box { return &AC4SpecificBox{} }
+}
+
+func (b AC4SpecificBox) Mp4BoxType() BoxType {
+	return Dac4BoxType
+}
+
+// SampleRate returns the sampling frequency in Hz.
+func (b *AC4SpecificBox) SampleRate() uint32 {
+	if b.FsIndex == 0 {
+		return 44100
+	}
+	return 48000
+}
+
+func (b *AC4SpecificBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	// bit(3) ac4_dsi_version;
+	// bit(7) bitstream_version;
+	// bit(1) fs_index;
+	// bit(4) frame_rate_index;
+	// bit(9) n_presentations;
+	bits := 24
+	if b.BitstreamVersion > 1 {
+		bits += 1 // bit(1) b_program_id;
+		if b.BProgramID {
+			bits += 16 // bit(16) short_program_id;
+			bits += 1  // bit(1) b_uuid;
+			if b.BUUID {
+				bits += 128 // bit(8)[16] program_uuid;
+			}
+		}
+	}
+	// ac4_bitrate_dsi();
+	bits += 2  // bit(2) bit_rate_mode;
+	bits += 32 // bit(32) bit_rate;
+	bits += 32 // bit(32) bit_rate_precision;
+	// byte_align;
+	b.Size += uint32(bits+7) / 8
+	for i := range b.Presentations {
+		p := &b.Presentations[i]
+		b.Size += 1 // bit(8) presentation_version;
+		b.Size += 1 // bit(8) pres_bytes;
+		if len(p.Data) >= 255 {
+			b.Size += 2 // bit(16) add_pres_bytes;
+		}
+		b.Size += uint32(len(p.Data))
+	}
+	b.Size += uint32(len(b.UnknownData))
+	return b.Size
+}
+
+func (b *AC4SpecificBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize() {
+		err = fmt.Errorf("dac4 box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	data := make([]byte, b.Size-b.HeaderSize())
+	if _, err = io.ReadFull(r, data); err != nil {
+		return
+	}
+	br := newBitReader(data)
+	b.AC4DSIVersion = uint8(br.read(3))
+	b.BitstreamVersion = uint8(br.read(7))
+	b.FsIndex = uint8(br.read(1))
+	b.FrameRateIndex = uint8(br.read(4))
+	nPresentations := int(br.read(9))
+	b.BProgramID = false
+	b.BUUID = false
+	if b.BitstreamVersion > 1 {
+		b.BProgramID = br.readFlag()
+		if b.BProgramID {
+			b.ShortProgramID = uint16(br.read(16))
+			b.BUUID = br.readFlag()
+			if b.BUUID {
+				for i := range b.ProgramUUID {
+					b.ProgramUUID[i] = uint8(br.read(8))
+				}
+			}
+		}
+	}
+	b.BitRateMode = uint8(br.read(2))
+	b.BitRate = uint32(br.read(32))
+	b.BitRatePrecision = uint32(br.read(32))
+	br.byteAlign()
+	if br.err != nil {
+		err = fmt.Errorf("invalid dac4 box: %w", br.err)
+		return
+	}
+	b.Presentations = make([]AC4PresentationDSI, nPresentations)
+	for i := range b.Presentations {
+		p := &b.Presentations[i]
+		p.PresentationVersion = uint8(br.read(8))
+		presBytes := int(br.read(8))
+		if presBytes == 255 {
+			presBytes += int(br.read(16))
+		}
+		if br.err != nil {
+			break
+		}
+		if br.remaining() < presBytes*8 {
+			err = fmt.Errorf("dac4 presentation exceeds box boundary: %w", ErrInvalidFormat)
+			return
+		}
+		p.Data = make([]byte, presBytes)
+		for j := range p.Data {
+			p.Data[j] = uint8(br.read(8))
+		}
+		p.decode()
+	}
+	if br.err != nil {
+		err = fmt.Errorf("invalid dac4 box: %w", br.err)
+		return
+	}
+	b.UnknownData = nil
+	if rest := br.rest(); len(rest) > 0 {
+		b.UnknownData = rest
+	}
+	return
+}
+
+func (b *AC4SpecificBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if len(b.Presentations) >= 1<<9 {
+		err = fmt.Errorf("dac4 box has too many presentations: %w", ErrInvalidFormat)
+		return
+	}
+	for i := range b.Presentations {
+		if len(b.Presentations[i].Data) >= 255+1<<16 {
+			err = fmt.Errorf("dac4 presentation too large: %w", ErrInvalidFormat)
+			return
+		}
+	}
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	var bw bitWriter
+	bw.write(uint64(b.AC4DSIVersion), 3)
+	bw.write(uint64(b.BitstreamVersion), 7)
+	bw.write(uint64(b.FsIndex), 1)
+	bw.write(uint64(b.FrameRateIndex), 4)
+	bw.write(uint64(len(b.Presentations)), 9)
+	if b.BitstreamVersion > 1 {
+		bw.writeFlag(b.BProgramID)
+		if b.BProgramID {
+			bw.write(uint64(b.ShortProgramID), 16)
+			bw.writeFlag(b.BUUID)
+			if b.BUUID {
+				for _, v := range b.ProgramUUID {
+					bw.write(uint64(v), 8)
+				}
+			}
+		}
+	}
+	bw.write(uint64(b.BitRateMode), 2)
+	bw.write(uint64(b.BitRate), 32)
+	bw.write(uint64(b.BitRatePrecision), 32)
+	for i := range b.Presentations {
+		p := &b.Presentations[i]
+		bw.byteAlign()
+		bw.write(uint64(p.PresentationVersion), 8)
+		if len(p.Data) >= 255 {
+			bw.write(255, 8)
+			bw.write(uint64(len(p.Data)-255), 16)
+		} else {
+			bw.write(uint64(len(p.Data)), 8)
+		}
+		bw.writeBytes(p.Data)
+	}
+	if _, err = w.Write(bw.bytes()); err != nil {
+		return
+	}
+	if _, err = w.Write(b.UnknownData); err != nil {
+		return
+	}
+	return
+}
+
+// decode interprets the leading fields of the presentation payload. Unknown
+// presentation versions and truncated payloads leave the fields unset.
+func (p *AC4PresentationDSI) decode() {
+	v := AC4PresentationDSI{PresentationVersion: p.PresentationVersion, Data: p.Data}
+	*p = v
+	if p.PresentationVersion > 2 {
+		return
+	}
+	br := newBitReader(p.Data)
+	v.PresentationConfig = uint8(br.read(5))
+	if v.PresentationConfig != 0x06 {
+		v.MDCompat = uint8(br.read(3))
+		v.BPresentationID = br.readFlag()
+		if v.BPresentationID {
+			v.PresentationID = uint8(br.read(5))
+		}
+		v.DSIFrameRateMultiplyInfo = uint8(br.read(2))
+		if v.PresentationVersion == 0 {
+			v.PresentationEMDFVersion = uint8(br.read(5))
+			v.PresentationKeyID = uint16(br.read(10))
+			v.BPresentationChannelCoded = true
+			v.PresentationChannelMask = uint32(br.read(24))
+		} else {
+			v.DSIFrameRateFractionInfo = uint8(br.read(2))
+			v.PresentationEMDFVersion = uint8(br.read(5))
+			v.PresentationKeyID = uint16(br.read(10))
+			v.BPresentationChannelCoded = br.readFlag()
+			if v.BPresentationChannelCoded {
+				v.DSIPresentationChMode = uint8(br.read(5))
+				if v.DSIPresentationChMode >= 11 && v.DSIPresentationChMode <= 14 {
+					v.PresBFourBackChannelsPresent = br.readFlag()
+					v.PresTopChannelPairs = uint8(br.read(2))
+				}
+				v.PresentationChannelMask = uint32(br.read(24))
+			}
+		}
+	}
+	if br.err != nil {
+		return
+	}
+	*p = v
+}
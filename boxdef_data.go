@@ -0,0 +1,163 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+// QuickTime File Format, Metadata, Value Atom
+
+// Box Type: ‘data’
+// Container: Metadata item of an Item List Box (‘ilst’)
+// Mandatory: Yes
+// Quantity: One or more
+
+// The DataBox holds the value of a metadata item. Its type indicator tells how
+// the value is encoded, e.g. as UTF‐8 text, a big endian integer or a JPEG
+// image. Items such as cover art may contain several values.
+//
+// The box is only read as a child of a metadata item, as the type ‘data’ is
+// used for other purposes elsewhere.
+type DataBox struct {
+	Header
+	NullContainer
+
+	// is the type indicator. The high byte is the type set, which is 0 for
+	// the well‐known types.
+	DataType MetadataDataType
+
+	// is the locale indicator, 0 for the default locale. The upper 16 bits
+	// are the country and the lower 16 bits the language.
+	Locale uint32
+
+	Value []byte
+}
+
+type MetadataDataType uint32
+
+const (
+	MetadataDataTypeBinary        MetadataDataType = 0
+	MetadataDataTypeUTF8          MetadataDataType = 1
+	MetadataDataTypeUTF16         MetadataDataType = 2
+	MetadataDataTypeUTF8Sort      MetadataDataType = 4
+	MetadataDataTypeUTF16Sort     MetadataDataType = 5
+	MetadataDataTypeJPEG          MetadataDataType = 13
+	MetadataDataTypePNG           MetadataDataType = 14
+	MetadataDataTypeBESignedInt   MetadataDataType = 21
+	MetadataDataTypeBEUnsignedInt MetadataDataType = 22
+	MetadataDataTypeBEFloat32     MetadataDataType = 23
+	MetadataDataTypeBEFloat64     MetadataDataType = 24
+	MetadataDataTypeBMP           MetadataDataType = 27
+	MetadataDataTypeMetadataAtom  MetadataDataType = 28
+)
+
+var _ Box = (*DataBox)(nil)
+
+func (b DataBox) Mp4BoxType() BoxType {
+	return DataBoxType
+}
+
+// NewStringDataBox returns a DataBox holding value as UTF‐8 text.
+func NewStringDataBox(value string) *DataBox {
+	return &DataBox{DataType: MetadataDataTypeUTF8, Value: []byte(value)}
+}
+
+// NewIntegerDataBox returns a DataBox holding value as a big endian signed
+// integer of size bytes, which is 1, 2, 4 or 8.
+func NewIntegerDataBox(value int64, size int) *DataBox {
+	b := &DataBox{DataType: MetadataDataTypeBESignedInt, Value: make([]byte, size)}
+	for i := 0; i < size; i++ {
+		b.Value[size-1-i] = byte(value >> (8 * i))
+	}
+	return b
+}
+
+// Text returns the value as text if it has one of the UTF‐8 or UTF‐16 types.
+// Other types result in an empty string.
+func (b *DataBox) Text() string {
+	switch b.DataType {
+	case MetadataDataTypeUTF8, MetadataDataTypeUTF8Sort:
+		return string(b.Value)
+	case MetadataDataTypeUTF16, MetadataDataTypeUTF16Sort:
+		u := make([]uint16, len(b.Value)/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(b.Value[2*i:])
+		}
+		return string(utf16.Decode(u))
+	}
+	return ""
+}
+
+// Integer returns the value as an integer. The value is accepted if it is a
+// big endian integer of 1 to 8 bytes, or if it has the binary type and fits in
+// 8 bytes, as some writers use it for integer items. Unsigned values of 8
+// bytes that exceed the range of int64 are not accepted.
+func (b *DataBox) Integer() (value int64, ok bool) {
+	if len(b.Value) == 0 || len(b.Value) > 8 {
+		return
+	}
+	switch b.DataType {
+	case MetadataDataTypeBESignedInt:
+		value = int64(int8(b.Value[0]))
+	case MetadataDataTypeBEUnsignedInt, MetadataDataTypeBinary:
+		if len(b.Value) == 8 && b.Value[0]&0x80 > 0 {
+			return
+		}
+		value = int64(b.Value[0])
+	default:
+		return
+	}
+	for _, c := range b.Value[1:] {
+		value = value<<8 | int64(c)
+	}
+	ok = true
+	return
+}
+
+func (b *DataBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 4 // type indicator
+	b.Size += 4 // locale indicator
+	b.Size += uint32(len(b.Value))
+	return b.Size
+}
+
+func (b *DataBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize()+8 {
+		err = fmt.Errorf("data box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.DataType); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Locale); err != nil {
+		return
+	}
+	b.Value = make([]byte, b.Size-b.HeaderSize()-8)
+	if _, err = io.ReadFull(r, b.Value); err != nil {
+		return
+	}
+	return
+}
+
+func (b *DataBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.DataType); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Locale); err != nil {
+		return
+	}
+	if _, err = w.Write(b.Value); err != nil {
+		return
+	}
+	return
+}
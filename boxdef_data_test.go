@@ -0,0 +1,28 @@
+package mp4
+
+import "testing"
+
+func TestDataBoxInteger(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		dataType MetadataDataType
+		value    []byte
+		want     int64
+		ok       bool
+	}{
+		{"signed byte", MetadataDataTypeBESignedInt, []byte{0xFF}, -1, true},
+		{"unsigned byte", MetadataDataTypeBEUnsignedInt, []byte{0xFF}, 0xFF, true},
+		{"signed minimum", MetadataDataTypeBESignedInt, []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, -1 << 63, true},
+		{"unsigned maximum", MetadataDataTypeBEUnsignedInt, []byte{0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, 1<<63 - 1, true},
+		{"unsigned overflow", MetadataDataTypeBEUnsignedInt, []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, 0, false},
+		{"binary overflow", MetadataDataTypeBinary, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, 0, false},
+		{"too long", MetadataDataTypeBESignedInt, make([]byte, 9), 0, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			b := &DataBox{DataType: test.dataType, Value: test.value}
+			if value, ok := b.Integer(); value != test.want || ok != test.ok {
+				t.Errorf("got %d, %t, want %d, %t", value, ok, test.want, test.ok)
+			}
+		})
+	}
+}
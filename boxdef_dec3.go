@@ -0,0 +1,253 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// ETSI TS 102 366 F.6 EC3SpecificBox
+
+// Box Type: ‘dec3’
+// Container: EC3SampleEntry (‘ec-3’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The EC3SpecificBox describes the independent substreams of an Enhanced AC‐3
+// bit stream and the dependent substreams associated with each of them.
+//
+// Streams carrying object audio using Joint Object Coding (JOC), such as Dolby
+// Atmos in E‐AC‐3, signal this with the type A extension and its complexity
+// index, which gives the number of objects to be decoded.
+type EC3SpecificBox struct {
+	Header
+	NullContainer
+
+	// indicates the data rate of the Enhanced AC‐3 bit stream in kbit/s.
+	DataRate uint16
+
+	// describes the independent substreams. The stream contains
+	// num_ind_sub + 1 independent substreams.
+	IndependentSubstreams []EC3IndependentSubstream
+
+	// indicates that the type A extension (JOC) is present.
+	FlagEC3ExtensionTypeA bool
+
+	// indicates the decoding complexity of the JOC coded content, which is
+	// the maximum number of objects.
+	ComplexityIndexTypeA uint8
+
+	// holds any data following the substream descriptions and the extension.
+	UnknownData []byte
+}
+
+type EC3IndependentSubstream struct {
+	Fscod AC3SampleRateCode
+	Bsid  uint8
+
+	// indicates that this independent substream contains an associated
+	// service.
+	Asvc bool
+
+	Bsmod AC3BitStreamMode
+	Acmod AC3AudioCodingMode
+	Lfeon bool
+
+	// indicates the number of dependent substreams associated with this
+	// independent substream.
+	NumDepSub uint8
+
+	// specifies the channel locations of the dependent substreams associated
+	// with this independent substream, if NumDepSub is not 0.
+	ChanLoc EC3ChannelLocation
+}
+
+var _ Box = (*EC3SpecificBox)(nil)
+
+func init() {
+	BoxRegistry[Dec3BoxType] = func() Box { return &EC3SpecificBox{} }
+}
+
+func (b EC3SpecificBox) Mp4BoxType() BoxType {
+	return Dec3BoxType
+}
+
+// ChannelCount returns the number of output channels of the first independent
+// substream and its dependent substreams, including LFE channels.
+func (b *EC3SpecificBox) ChannelCount() (count uint8) {
+	if len(b.IndependentSubstreams) == 0 {
+		return
+	}
+	sub := &b.IndependentSubstreams[0]
+	count = sub.Acmod.ChannelCount()
+	if sub.Lfeon {
+		count++
+	}
+	if sub.NumDepSub > 0 {
+		count += sub.ChanLoc.ChannelCount()
+	}
+	return
+}
+
+// IsJOC reports whether the stream carries Joint Object Coding content.
+func (b *EC3SpecificBox) IsJOC() bool {
+	return b.FlagEC3ExtensionTypeA
+}
+
+func (b *EC3SpecificBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 2 // bit(13) data_rate; bit(3) num_ind_sub;
+	for i := range b.IndependentSubstreams {
+		// bit(2) fscod;
+		// bit(5) bsid;
+		// bit(1) reserved = 0;
+		// bit(1) asvc;
+		// bit(3) bsmod;
+		// bit(3) acmod;
+		// bit(1) lfeon;
+		// bit(3) reserved = 0;
+		// bit(4) num_dep_sub;
+		// if (num_dep_sub > 0) bit(9) chan_loc; else bit(1) reserved = 0;
+		if b.IndependentSubstreams[i].NumDepSub > 0 {
+			b.Size += 4
+		} else {
+			b.Size += 3
+		}
+	}
+	if b.FlagEC3ExtensionTypeA {
+		b.Size += 1 // bit(7) reserved = 0; bit(1) flag_ec3_extension_type_a;
+		b.Size += 1 // unsigned int(8) complexity_index_type_a;
+	}
+	b.Size += uint32(len(b.UnknownData))
+	return b.Size
+}
+
+func (b *EC3SpecificBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize() {
+		err = fmt.Errorf("dec3 box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	data := make([]byte, b.Size-b.HeaderSize())
+	if _, err = io.ReadFull(r, data); err != nil {
+		return
+	}
+	br := newBitReader(data)
+	b.DataRate = uint16(br.read(13))
+	numIndSub := int(br.read(3))
+	b.IndependentSubstreams = make([]EC3IndependentSubstream, numIndSub+1)
+	for i := range b.IndependentSubstreams {
+		sub := &b.IndependentSubstreams[i]
+		sub.Fscod = AC3SampleRateCode(br.read(2))
+		sub.Bsid = uint8(br.read(5))
+		br.skip(1) // reserved
+		sub.Asvc = br.readFlag()
+		sub.Bsmod = AC3BitStreamMode(br.read(3))
+		sub.Acmod = AC3AudioCodingMode(br.read(3))
+		sub.Lfeon = br.readFlag()
+		br.skip(3) // reserved
+		sub.NumDepSub = uint8(br.read(4))
+		if sub.NumDepSub > 0 {
+			sub.ChanLoc = EC3ChannelLocation(br.read(9))
+		} else {
+			br.skip(1) // reserved
+		}
+	}
+	if br.err != nil {
+		err = fmt.Errorf("invalid dec3 box: %w", br.err)
+		return
+	}
+	b.FlagEC3ExtensionTypeA = false
+	b.ComplexityIndexTypeA = 0
+	// the extension is only consumed if it is signalled, otherwise the
+	// remaining bytes are kept as they are.
+	if br.remaining() >= 16 && data[br.pos>>3]&1 != 0 {
+		br.skip(7) // reserved
+		b.FlagEC3ExtensionTypeA = br.readFlag()
+		b.ComplexityIndexTypeA = uint8(br.read(8))
+	}
+	b.UnknownData = nil
+	if rest := br.rest(); len(rest) > 0 {
+		b.UnknownData = rest
+	}
+	return
+}
+
+func (b *EC3SpecificBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if len(b.IndependentSubstreams) == 0 || len(b.IndependentSubstreams) > 8 {
+		err = fmt.Errorf("dec3 box must have 1 to 8 independent substreams: %w", ErrInvalidFormat)
+		return
+	}
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	var bw bitWriter
+	bw.write(uint64(b.DataRate), 13)
+	bw.write(uint64(len(b.IndependentSubstreams)-1), 3)
+	for i := range b.IndependentSubstreams {
+		sub := &b.IndependentSubstreams[i]
+		bw.write(uint64(sub.Fscod), 2)
+		bw.write(uint64(sub.Bsid), 5)
+		bw.write(0, 1) // reserved
+		bw.writeFlag(sub.Asvc)
+		bw.write(uint64(sub.Bsmod), 3)
+		bw.write(uint64(sub.Acmod), 3)
+		bw.writeFlag(sub.Lfeon)
+		bw.write(0, 3) // reserved
+		bw.write(uint64(sub.NumDepSub), 4)
+		if sub.NumDepSub > 0 {
+			bw.write(uint64(sub.ChanLoc), 9)
+		} else {
+			bw.write(0, 1) // reserved
+		}
+	}
+	if b.FlagEC3ExtensionTypeA {
+		bw.write(0, 7) // reserved
+		bw.writeFlag(b.FlagEC3ExtensionTypeA)
+		bw.write(uint64(b.ComplexityIndexTypeA), 8)
+	}
+	if _, err = w.Write(bw.bytes()); err != nil {
+		return
+	}
+	if _, err = w.Write(b.UnknownData); err != nil {
+		return
+	}
+	return
+}
+
+// ETSI TS 102 366 Table F.6.1 chan_loc field bit assignments
+
+// EC3ChannelLocation is a 9‐bit mask of the channel locations carried in
+// dependent substreams, in addition to those of the independent substream.
+type EC3ChannelLocation uint16
+
+const (
+	EC3ChannelLocationLcRc   EC3ChannelLocation = 1 << 8 // Lc/Rc pair
+	EC3ChannelLocationLrsRrs EC3ChannelLocation = 1 << 7 // Lrs/Rrs pair
+	EC3ChannelLocationCs     EC3ChannelLocation = 1 << 6
+	EC3ChannelLocationTs     EC3ChannelLocation = 1 << 5
+	EC3ChannelLocationLsdRsd EC3ChannelLocation = 1 << 4 // Lsd/Rsd pair
+	EC3ChannelLocationLwRw   EC3ChannelLocation = 1 << 3 // Lw/Rw pair
+	EC3ChannelLocationLvhRvh EC3ChannelLocation = 1 << 2 // Lvh/Rvh pair
+	EC3ChannelLocationCvh    EC3ChannelLocation = 1 << 1
+	EC3ChannelLocationLFE2   EC3ChannelLocation = 1 << 0
+)
+
+// ChannelCount returns the number of channels signalled by the mask.
+func (l EC3ChannelLocation) ChannelCount() (count uint8) {
+	pairs := EC3ChannelLocationLcRc | EC3ChannelLocationLrsRrs | EC3ChannelLocationLsdRsd |
+		EC3ChannelLocationLwRw | EC3ChannelLocationLvhRvh
+	for bit := EC3ChannelLocation(1); bit < 1<<9; bit <<= 1 {
+		if l&bit == 0 {
+			continue
+		}
+		if pairs&bit != 0 {
+			count += 2
+		} else {
+			count++
+		}
+	}
+	return
+}
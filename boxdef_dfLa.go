@@ -0,0 +1,178 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Encapsulation of FLAC in ISO Base Media File Format 3.3.2 FLAC Specific Box
+
+// Box Type: ‘dfLa’
+// Container: FLAC Sample Entry (‘fLaC’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The FLACSpecificBox contains the METADATA_BLOCKs of the FLAC stream header.
+// The first block shall be the STREAMINFO block, and the last block has its
+// last‐metadata‐block flag set, which is done automatically when writing.
+type FLACSpecificBox struct {
+	FullHeader
+	NullContainer
+
+	MetadataBlocks []FLACMetadataBlock
+}
+
+type FLACMetadataBlock struct {
+	BlockType FLACMetadataBlockType
+
+	// is the block data without the METADATA_BLOCK_HEADER.
+	BlockData []byte
+}
+
+type FLACMetadataBlockType uint8
+
+const (
+	FLACMetadataBlockTypeStreamInfo    FLACMetadataBlockType = 0
+	FLACMetadataBlockTypePadding       FLACMetadataBlockType = 1
+	FLACMetadataBlockTypeApplication   FLACMetadataBlockType = 2
+	FLACMetadataBlockTypeSeekTable     FLACMetadataBlockType = 3
+	FLACMetadataBlockTypeVorbisComment FLACMetadataBlockType = 4
+	FLACMetadataBlockTypeCueSheet      FLACMetadataBlockType = 5
+	FLACMetadataBlockTypePicture       FLACMetadataBlockType = 6
+)
+
+var _ Box = (*FLACSpecificBox)(nil)
+
+func init() {
+	BoxRegistry[DfLaBoxType] = func() Box { return &FLACSpecificBox{} }
+}
+
+func (b FLACSpecificBox) Mp4BoxType() BoxType {
+	return DfLaBoxType
+}
+
+// StreamInfo decodes the STREAMINFO block, which is the first metadata block.
+func (b *FLACSpecificBox) StreamInfo() (info *FLACStreamInfo, err error) {
+	if len(b.MetadataBlocks) == 0 || b.MetadataBlocks[0].BlockType != FLACMetadataBlockTypeStreamInfo {
+		err = fmt.Errorf("dfLa box has no STREAMINFO block: %w", ErrInvalidFormat)
+		return
+	}
+	return ParseFLACStreamInfo(b.MetadataBlocks[0].BlockData)
+}
+
+func (b *FLACSpecificBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	for i := range b.MetadataBlocks {
+		b.Size += 1 // unsigned int(1) LastMetadataBlockFlag; unsigned int(7) BlockType;
+		b.Size += 3 // unsigned int(24) Length;
+		b.Size += uint32(len(b.MetadataBlocks[i].BlockData))
+	}
+	return b.Size
+}
+
+func (b *FLACSpecificBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.MetadataBlocks = nil
+	remainingSize := int64(b.Size) - int64(b.headerSize())
+	for remainingSize > 0 {
+		var blockHeader uint32
+		if err = binary.Read(r, binary.BigEndian, &blockHeader); err != nil {
+			return
+		}
+		block := FLACMetadataBlock{BlockType: FLACMetadataBlockType(blockHeader >> 24 & 0x7F)}
+		length := blockHeader & 0xFFFFFF
+		remainingSize -= 4 + int64(length)
+		if remainingSize < 0 {
+			err = fmt.Errorf("dfLa metadata block exceeds box boundary: %w", ErrInvalidFormat)
+			return
+		}
+		block.BlockData = make([]byte, length)
+		if _, err = io.ReadFull(r, block.BlockData); err != nil {
+			return
+		}
+		b.MetadataBlocks = append(b.MetadataBlocks, block)
+		if blockHeader>>31 != 0 {
+			break
+		}
+	}
+	if remainingSize != 0 {
+		err = fmt.Errorf("dfLa box has data after the last metadata block: %w", ErrInvalidFormat)
+		return
+	}
+	return
+}
+
+func (b *FLACSpecificBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	for i := range b.MetadataBlocks {
+		block := &b.MetadataBlocks[i]
+		if len(block.BlockData) >= 1<<24 {
+			err = fmt.Errorf("dfLa metadata block too large: %w", ErrInvalidFormat)
+			return
+		}
+		blockHeader := uint32(block.BlockType&0x7F)<<24 | uint32(len(block.BlockData))
+		if i == len(b.MetadataBlocks)-1 {
+			blockHeader |= 1 << 31
+		}
+		if err = binary.Write(w, binary.BigEndian, blockHeader); err != nil {
+			return
+		}
+		if _, err = w.Write(block.BlockData); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// FLAC format METADATA_BLOCK_STREAMINFO
+type FLACStreamInfo struct {
+	MinimumBlockSize uint16
+	MaximumBlockSize uint16
+	MinimumFrameSize uint32
+	MaximumFrameSize uint32
+	SampleRate       uint32
+	Channels         uint8
+	BitsPerSample    uint8
+	TotalSamples     uint64
+	MD5              [16]byte
+}
+
+func ParseFLACStreamInfo(data []byte) (info *FLACStreamInfo, err error) {
+	if len(data) != 34 {
+		err = fmt.Errorf("STREAMINFO block has invalid length %d: %w", len(data), ErrInvalidFormat)
+		return
+	}
+	br := newBitReader(data)
+	info = &FLACStreamInfo{}
+	info.MinimumBlockSize = uint16(br.read(16))
+	info.MaximumBlockSize = uint16(br.read(16))
+	info.MinimumFrameSize = uint32(br.read(24))
+	info.MaximumFrameSize = uint32(br.read(24))
+	info.SampleRate = uint32(br.read(20))
+	info.Channels = uint8(br.read(3)) + 1
+	info.BitsPerSample = uint8(br.read(5)) + 1
+	info.TotalSamples = br.read(36)
+	copy(info.MD5[:], data[18:])
+	return
+}
+
+// Bytes encodes the STREAMINFO block data.
+func (info *FLACStreamInfo) Bytes() []byte {
+	var bw bitWriter
+	bw.write(uint64(info.MinimumBlockSize), 16)
+	bw.write(uint64(info.MaximumBlockSize), 16)
+	bw.write(uint64(info.MinimumFrameSize), 24)
+	bw.write(uint64(info.MaximumFrameSize), 24)
+	bw.write(uint64(info.SampleRate), 20)
+	bw.write(uint64(info.Channels-1), 3)
+	bw.write(uint64(info.BitsPerSample-1), 5)
+	bw.write(info.TotalSamples, 36)
+	bw.writeBytes(info.MD5[:])
+	return bw.bytes()
+}
@@ -54,3 +54,15 @@ func (b *DataInformationBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// newDataInformationBox returns a data information box whose single data
+// reference declares that the media data is in the same file.
+func newDataInformationBox() *DataInformationBox {
+	url := &DataEntryBox{}
+	url.Mp4BoxSetFlags(FLAG_DREF_SAME_FILE)
+	dref := &DataReferenceBox{}
+	dref.Mp4BoxAppend(url)
+	dinf := &DataInformationBox{}
+	dinf.Mp4BoxAppend(dref)
+	return dinf
+}
@@ -0,0 +1,61 @@
+package mp4
+
+import (
+	"io"
+)
+
+// 8.6.5 Edit Box
+
+// Box Type: ‘edts’
+// Container: Track Box (‘trak’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// An Edit Box maps the presentation time‐line to the media time‐line as it is
+// stored in the file. The Edit Box is a container for the edit lists.
+//
+// The Edit Box is optional. In the absence of this box, there is an implicit
+// one‐to‐one mapping of these time‐lines, and the presentation of a track
+// starts at the beginning of the presentation. An empty edit is used to offset
+// the start time of a track.
+type EditBox struct {
+	Header
+	Container
+}
+
+var _ Box = (*EditBox)(nil)
+
+func init() {
+	BoxRegistry[EdtsBoxType] = func() Box { return &EditBox{} }
+}
+
+func (b EditBox) Mp4BoxType() BoxType {
+	return EdtsBoxType
+}
+
+func (b *EditBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *EditBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.HeaderSize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *EditBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
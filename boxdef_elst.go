@@ -0,0 +1,173 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.6.6 Edit List Box
+
+// Box Type: ‘elst’
+// Container: Edit Box (‘edts’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// This box contains an explicit timeline map. Each entry defines part of the
+// track time‐line: by mapping part of the media time‐line, or by indicating
+// ‘empty’ time, or by defining a ‘dwell’, where a single time‐point in the
+// media is held for a period.
+//
+// Starting offsets for tracks (streams) are represented by an initial empty
+// edit. For example, to play a track from its start for 30 seconds, but at 10
+// seconds into the presentation, we have the following edit list:
+//
+//     Entry-count = 2
+//     Segment-duration = 10 seconds
+//     Media-Time = -1
+//     Media-Rate = 1
+//
+//     Segment-duration = 30 seconds (could be the length of the whole track)
+//     Media-Time = 0 seconds
+//     Media-Rate = 1
+type EditListBox struct {
+	FullHeader
+	NullContainer
+	Entries []EditListEntry
+}
+
+var _ Box = (*EditListBox)(nil)
+
+func init() {
+	BoxRegistry[ElstBoxType] = func() Box { return &EditListBox{} }
+}
+
+type EditListEntry struct {
+	// is an integer that specifies the duration of this edit in units of the
+	// timescale in the Movie Header Box. Version 1 of the box is needed for
+	// values exceeding 32 bits.
+	SegmentDuration uint64
+
+	// is an integer containing the starting time within the media of this
+	// edit entry (in media time scale units, in composition time). If this
+	// field is set to –1, it is an empty edit. The last edit in a track shall
+	// never be an empty edit.
+	MediaTime int64
+
+	// specifies the relative rate at which to play the media corresponding to
+	// this edit entry. If this value is 0, then the edit is specifying a
+	// ‘dwell’: the media at media‐time is presented for the
+	// segment‐duration. Otherwise this field shall contain the value 1.
+	MediaRateInteger  int16
+	MediaRateFraction int16
+}
+
+func (b EditListBox) Mp4BoxType() BoxType {
+	return ElstBoxType
+}
+
+func (b *EditListBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 4 // unsigned int(32) entry_count;
+	if b.Version == 1 {
+		// for (i=1; i <= entry_count; i++) {
+		//     unsigned int(64) segment_duration;
+		//     int(64) media_time;
+		//     int(16) media_rate_integer;
+		//     int(16) media_rate_fraction = 0;
+		// }
+		b.Size += 20 * uint32(len(b.Entries))
+	} else {
+		// for (i=1; i <= entry_count; i++) {
+		//     unsigned int(32) segment_duration;
+		//     int(32) media_time;
+		//     int(16) media_rate_integer;
+		//     int(16) media_rate_fraction = 0;
+		// }
+		b.Size += 12 * uint32(len(b.Entries))
+	}
+	return b.Size
+}
+
+func (b *EditListBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	var entryCount uint32
+	if err = binary.Read(r, binary.BigEndian, &entryCount); err != nil {
+		return
+	}
+	b.Entries = make([]EditListEntry, entryCount)
+	for i := range b.Entries {
+		entry := &b.Entries[i]
+		if b.Version == 1 {
+			if err = binary.Read(r, binary.BigEndian, &entry.SegmentDuration); err != nil {
+				return
+			}
+			if err = binary.Read(r, binary.BigEndian, &entry.MediaTime); err != nil {
+				return
+			}
+		} else {
+			var tmp struct {
+				SegmentDuration uint32
+				MediaTime       int32
+			}
+			if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+				return
+			}
+			entry.SegmentDuration = uint64(tmp.SegmentDuration)
+			entry.MediaTime = int64(tmp.MediaTime)
+		}
+		if err = binary.Read(r, binary.BigEndian, &entry.MediaRateInteger); err != nil {
+			return
+		}
+		if err = binary.Read(r, binary.BigEndian, &entry.MediaRateFraction); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *EditListBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(len(b.Entries))); err != nil {
+		return
+	}
+	for _, entry := range b.Entries {
+		if b.Version == 1 {
+			if err = binary.Write(w, binary.BigEndian, entry.SegmentDuration); err != nil {
+				return
+			}
+			if err = binary.Write(w, binary.BigEndian, entry.MediaTime); err != nil {
+				return
+			}
+		} else {
+			if err = binary.Write(w, binary.BigEndian, uint32(entry.SegmentDuration)); err != nil {
+				return
+			}
+			if err = binary.Write(w, binary.BigEndian, int32(entry.MediaTime)); err != nil {
+				return
+			}
+		}
+		if err = binary.Write(w, binary.BigEndian, entry.MediaRateInteger); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, entry.MediaRateFraction); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// needsVersion1 reports whether an entry does not fit into version 0 of the
+// box.
+func (b *EditListBox) needsVersion1() bool {
+	for _, entry := range b.Entries {
+		if entry.SegmentDuration > 0xFFFFFFFF || entry.MediaTime < -1<<31 || entry.MediaTime >= 1<<31 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// ISO/IEC 14496‐14 6.7.2 ES Descriptor Box
+
+// Box Type: ‘esds’
+// Container: Sample Entry (‘mp4a’, ‘mp4v’, ‘mp4s’, ‘enca’, ‘encv’, ‘encs’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The ES Descriptor Box carries the ES_Descriptor of the elementary stream
+// described by the sample entry. The stream type and object type indication
+// of its DecoderConfigDescriptor identify the coding format, and the decoder
+// specific information carries e.g. the AudioSpecificConfig of an AAC stream.
+type ElementaryStreamDescriptorBox struct {
+	FullHeader
+	NullContainer
+
+	ESDescriptor ESDescriptor
+
+	// holds any data following the ES_Descriptor.
+	UnknownData []byte
+}
+
+var _ Box = (*ElementaryStreamDescriptorBox)(nil)
+
+func init() {
+	BoxRegistry[EsdsBoxType] = func() Box { return &ElementaryStreamDescriptorBox{} }
+}
+
+func (b ElementaryStreamDescriptorBox) Mp4BoxType() BoxType {
+	return EsdsBoxType
+}
+
+func (b *ElementaryStreamDescriptorBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += b.ESDescriptor.DescriptorUpdate() // ES_Descriptor ES;
+	b.Size += uint32(len(b.UnknownData))
+	return b.Size
+}
+
+func (b *ElementaryStreamDescriptorBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	var descriptorHeader *DescriptorHeader
+	if descriptorHeader, err = ReadDescriptorHeader(r); err != nil {
+		return
+	}
+	if descriptorHeader.Tag != ESDescrTag {
+		err = fmt.Errorf("esds got descriptor tag 0x%02x instead of ES_Descriptor: %w", descriptorHeader.Tag, ErrInvalidFormat)
+		return
+	}
+	// the size of the descriptor is checked before its payload is read.
+	used := b.headerSize() + descriptorHeader.DescriptorSize()
+	if used > b.Size {
+		err = fmt.Errorf("esds descriptor exceeds box boundary: %w", ErrInvalidFormat)
+		return
+	}
+	if err = b.ESDescriptor.DescriptorRead(r, descriptorHeader); err != nil {
+		return
+	}
+	b.UnknownData = nil
+	if used < b.Size {
+		b.UnknownData = make([]byte, b.Size-used)
+		if _, err = io.ReadFull(r, b.UnknownData); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *ElementaryStreamDescriptorBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.ESDescriptor.DescriptorWrite(w); err != nil {
+		return
+	}
+	if _, err = w.Write(b.UnknownData); err != nil {
+		return
+	}
+	return
+}
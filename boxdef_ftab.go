@@ -0,0 +1,101 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 3GPP TS 26.245 5.16 Sample Description Format
+
+// Box Types: ‘ftab’
+// Container: Text Sample Entry (‘tx3g’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The FontTableBox maps the font identifiers used in style records to font
+// names.
+type FontTableBox struct {
+	Header
+	NullContainer
+
+	Entries []FontRecord
+}
+
+type FontRecord struct {
+	FontID   uint16
+	FontName string
+}
+
+var _ Box = (*FontTableBox)(nil)
+
+func init() {
+	BoxRegistry[FtabBoxType] = func() Box { return &FontTableBox{} }
+}
+
+func (b FontTableBox) Mp4BoxType() BoxType {
+	return FtabBoxType
+}
+
+func (b *FontTableBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 2 // unsigned int(16) entry-count;
+	for _, entry := range b.Entries {
+		b.Size += 2                           // unsigned int(16) font-ID;
+		b.Size += 1                           // unsigned int(8) font-name-length;
+		b.Size += uint32(len(entry.FontName)) // unsigned int(8) font[font-name-length];
+	}
+	return b.Size
+}
+
+func (b *FontTableBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	var entryCount uint16
+	if err = binary.Read(r, binary.BigEndian, &entryCount); err != nil {
+		return
+	}
+	b.Entries = make([]FontRecord, entryCount)
+	for i := range b.Entries {
+		if err = binary.Read(r, binary.BigEndian, &b.Entries[i].FontID); err != nil {
+			return
+		}
+		var length uint8
+		if err = binary.Read(r, binary.BigEndian, &length); err != nil {
+			return
+		}
+		name := make([]byte, length)
+		if _, err = io.ReadFull(r, name); err != nil {
+			return
+		}
+		b.Entries[i].FontName = string(name)
+	}
+	return
+}
+
+func (b *FontTableBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(len(b.Entries))); err != nil {
+		return
+	}
+	for _, entry := range b.Entries {
+		if len(entry.FontName) > 0xFF {
+			err = fmt.Errorf("ftab font name exceeds 255 bytes: %w", ErrInvalidFormat)
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, entry.FontID); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, uint8(len(entry.FontName))); err != nil {
+			return
+		}
+		if _, err = io.WriteString(w, entry.FontName); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,135 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// QuickTime File Format Base Media Information Header
+
+// Box Type: ‘gmhd’
+// Container: Media Information Box (‘minf’)
+// Mandatory: Yes
+// Quantity: Exactly one specific media header shall be present
+
+// QuickTime tracks whose media type has no specific media header, such as
+// timecode and text tracks, use the BaseMediaInformationHeaderBox. It contains
+// a BaseMediaInfoBox and, for timecode tracks, a TimecodeMediaBox.
+//
+// A ‘tmcd’ child is read as TimecodeMediaBox rather than as the timecode
+// sample entry registered for the type.
+type BaseMediaInformationHeaderBox struct {
+	Header
+	Container
+}
+
+// The TimecodeMediaBox holds the TimecodeMediaInformationBox of a timecode
+// track.
+type TimecodeMediaBox struct {
+	Header
+	Container
+}
+
+var _ Box = (*BaseMediaInformationHeaderBox)(nil)
+var _ Box = (*TimecodeMediaBox)(nil)
+
+func init() {
+	BoxRegistry[GmhdBoxType] = func() Box { return &BaseMediaInformationHeaderBox{} }
+}
+
+func (b BaseMediaInformationHeaderBox) Mp4BoxType() BoxType {
+	return GmhdBoxType
+}
+
+// BaseMediaInfo returns the ‘gmin’ box, or nil if there is none.
+func (b *BaseMediaInformationHeaderBox) BaseMediaInfo() *BaseMediaInfoBox {
+	gmin, _ := b.Mp4BoxFindFirst(GminBoxType).(*BaseMediaInfoBox)
+	return gmin
+}
+
+// TimecodeMediaInformation returns the ‘tcmi’ box of the ‘tmcd’ child, or nil
+// if there is none.
+func (b *BaseMediaInformationHeaderBox) TimecodeMediaInformation() *TimecodeMediaInformationBox {
+	if tmcd, ok := b.Mp4BoxFindFirst(TmcdBoxType).(*TimecodeMediaBox); ok {
+		tcmi, _ := tmcd.Mp4BoxFindFirst(TcmiBoxType).(*TimecodeMediaInformationBox)
+		return tcmi
+	}
+	return nil
+}
+
+func (b *BaseMediaInformationHeaderBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *BaseMediaInformationHeaderBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	remainingSize := int64(b.Size - b.HeaderSize())
+	for remainingSize > 0 {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			return
+		}
+		var child Box
+		if header.Type == TmcdBoxType {
+			child = &TimecodeMediaBox{}
+			if err = child.Mp4BoxRead(r, header); err != nil {
+				return
+			}
+		} else if child, err = ReadBoxAfterHeader(r, header); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *BaseMediaInformationHeaderBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
+
+func (b TimecodeMediaBox) Mp4BoxType() BoxType {
+	return TmcdBoxType
+}
+
+func (b *TimecodeMediaBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *TimecodeMediaBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.HeaderSize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *TimecodeMediaBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
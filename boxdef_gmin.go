@@ -0,0 +1,89 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// QuickTime File Format Base Media Info
+
+// Box Type: ‘gmin’
+// Container: Base Media Information Header Box (‘gmhd’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The BaseMediaInfoBox defines the graphics and audio characteristics of media
+// types that use the base media information header, such as timecode and
+// QuickTime text tracks.
+type BaseMediaInfoBox struct {
+	FullHeader
+	NullContainer
+
+	// is the QuickDraw transfer mode, e.g. 0x0040 for dither copy.
+	GraphicsMode uint16
+
+	// is the red, green and blue colour for the transfer mode operation.
+	OpColor [3]uint16
+
+	// is a fixed‐point 8.8 number for the stereo balance of audio.
+	Balance int16
+}
+
+var _ Box = (*BaseMediaInfoBox)(nil)
+
+func init() {
+	BoxRegistry[GminBoxType] = func() Box { return &BaseMediaInfoBox{} }
+}
+
+func (b BaseMediaInfoBox) Mp4BoxType() BoxType {
+	return GminBoxType
+}
+
+func (b *BaseMediaInfoBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 2     // unsigned int(16) graphics_mode;
+	b.Size += 2 * 3 // unsigned int(16)[3] opcolor;
+	b.Size += 2     // int(16) balance;
+	b.Size += 2     // const unsigned int(16) reserved = 0;
+	return b.Size
+}
+
+func (b *BaseMediaInfoBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.GraphicsMode); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.OpColor); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Balance); err != nil {
+		return
+	}
+	var reserved uint16
+	if err = binary.Read(r, binary.BigEndian, &reserved); err != nil {
+		return
+	}
+	return
+}
+
+func (b *BaseMediaInfoBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.GraphicsMode); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.OpColor); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Balance); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(0)); err != nil {
+		return
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package mp4
 
 import (
+	"bytes"
 	"encoding/binary"
 	"io"
 )
@@ -40,6 +41,10 @@ type HandlerBox struct {
 	// human‐readable name for the track type (for debugging and inspection
 	// purposes).
 	Name NullTerminatedString
+
+	// is set if the name is stored as a QuickTime Pascal string, with a
+	// length byte instead of the null terminator.
+	PascalName bool
 }
 
 var _ Box = (*HandlerBox)(nil)
@@ -59,6 +64,9 @@ func (b *HandlerBox) Mp4BoxUpdate() uint32 {
 	b.Size += 4             // unsigned int(32) handler_type;
 	b.Size += 4 * 3         // const unsigned int(32)[3] reserved = 0;
 	b.Size += b.Name.Size() // string name;
+	if b.PascalName && len(b.Name) > 0xFF {
+		b.PascalName = false
+	}
 	return b.Size
 }
 
@@ -71,8 +79,20 @@ func (b *HandlerBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
 		return
 	}
 	copy(b.HandlerType[:], tmp[4:8])
-	if err = b.Name.ReadOfSize(r, b.Size-b.headerSize()-20); err != nil {
-		return
+	b.Name = ""
+	b.PascalName = false
+	// the name is missing altogether in some metadata handler boxes
+	if size := b.Size - b.headerSize() - 20; size > 0 {
+		name := make([]byte, size)
+		if _, err = io.ReadFull(r, name); err != nil {
+			return
+		}
+		if int(name[0]) == len(name)-1 && name[len(name)-1] != 0 {
+			b.Name = NullTerminatedString(name[1:])
+			b.PascalName = true
+		} else if err = b.Name.ReadOfSize(bytes.NewReader(name), size); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -86,7 +106,11 @@ func (b *HandlerBox) Mp4BoxWrite(w io.Writer) (err error) {
 	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
 		return
 	}
-	if err = b.Name.Write(w); err != nil {
+	if b.PascalName {
+		if _, err = w.Write(append([]byte{byte(len(b.Name))}, b.Name...)); err != nil {
+			return
+		}
+	} else if err = b.Name.Write(w); err != nil {
 		return
 	}
 	return
@@ -0,0 +1,56 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 Cue ID Box
+
+// Box Types: ‘iden’
+// Container: WebVTT Cue Box (‘vttc’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The CueIDBox contains the identifier of the cue.
+type CueIDBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	CueID string
+}
+
+var _ Box = (*CueIDBox)(nil)
+
+func init() {
+	BoxRegistry[IdenBoxType] = func() Box { return &CueIDBox{} }
+}
+
+func (b CueIDBox) Mp4BoxType() BoxType {
+	return IdenBoxType
+}
+
+func (b *CueIDBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.CueID)) // boxstring cue_id;
+	return b.Size
+}
+
+func (b *CueIDBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.CueID, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *CueIDBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.CueID); err != nil {
+		return
+	}
+	return
+}
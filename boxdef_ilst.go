@@ -0,0 +1,204 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// QuickTime File Format, Metadata, Metadata Item List Atom
+
+// Box Type: ‘ilst’
+// Container: Meta Box (‘meta’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The ItemListBox holds the metadata items. With an ‘mdir’ handler the box
+// type of an item identifies it, e.g. ‘©nam’ for the title. With an ‘mdta’
+// handler the box type is the 1‐based index of the key in the KeysBox.
+//
+// All children are read as MetadataItemBox, whatever their type.
+type ItemListBox struct {
+	Header
+	Container
+}
+
+// The MetadataItemBox is a metadata item, its box type identifies the item.
+// It contains one or more DataBoxes with the values of the item. A freeform
+// (‘----’) item is identified by a MeanBox and a NameBox before the values.
+type MetadataItemBox struct {
+	Header
+	Container
+}
+
+// Item types of iTunes‐style metadata.
+var (
+	MetadataItemTitle           = BoxType{0xA9, 'n', 'a', 'm'}
+	MetadataItemArtist          = BoxType{0xA9, 'A', 'R', 'T'}
+	MetadataItemAlbumArtist     = BoxType{'a', 'A', 'R', 'T'}
+	MetadataItemAlbum           = BoxType{0xA9, 'a', 'l', 'b'}
+	MetadataItemComment         = BoxType{0xA9, 'c', 'm', 't'}
+	MetadataItemComposer        = BoxType{0xA9, 'w', 'r', 't'}
+	MetadataItemDate            = BoxType{0xA9, 'd', 'a', 'y'}
+	MetadataItemGenre           = BoxType{0xA9, 'g', 'e', 'n'}
+	MetadataItemEncodingTool    = BoxType{0xA9, 't', 'o', 'o'}
+	MetadataItemCopyright       = BoxType{'c', 'p', 'r', 't'}
+	MetadataItemTrackNumber     = BoxType{'t', 'r', 'k', 'n'}
+	MetadataItemDiskNumber      = BoxType{'d', 'i', 's', 'k'}
+	MetadataItemCoverArt        = BoxType{'c', 'o', 'v', 'r'}
+	MetadataItemDescription     = BoxType{'d', 'e', 's', 'c'}
+	MetadataItemLongDescription = BoxType{'l', 'd', 'e', 's'}
+	MetadataItemMediaKind       = BoxType{'s', 't', 'i', 'k'}
+	MetadataItemTVShow          = BoxType{'t', 'v', 's', 'h'}
+	MetadataItemTVNetwork       = BoxType{'t', 'v', 'n', 'n'}
+	MetadataItemTVEpisodeID     = BoxType{'t', 'v', 'e', 'n'}
+	MetadataItemTVSeason        = BoxType{'t', 'v', 's', 'n'}
+	MetadataItemTVEpisode       = BoxType{'t', 'v', 'e', 's'}
+	MetadataItemFreeform        = BoxType{'-', '-', '-', '-'}
+)
+
+var _ Box = (*ItemListBox)(nil)
+var _ Box = (*MetadataItemBox)(nil)
+
+func init() {
+	BoxRegistry[IlstBoxType] = func() Box { return &ItemListBox{} }
+}
+
+func (b ItemListBox) Mp4BoxType() BoxType {
+	return IlstBoxType
+}
+
+func (b *ItemListBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *ItemListBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	remainingSize := int64(b.Size - b.HeaderSize())
+	for remainingSize > 0 {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			return
+		}
+		child := &MetadataItemBox{}
+		if err = child.Mp4BoxRead(r, header); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *ItemListBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
+
+// KeyIndex returns the box type of an item of a QuickTime ‘mdta’ item list as
+// the 1‐based index into the KeysBox.
+func (b *MetadataItemBox) KeyIndex() uint32 {
+	return binary.BigEndian.Uint32(b.Type[:])
+}
+
+// Data returns the DataBoxes of the item.
+func (b *MetadataItemBox) Data() (data []*DataBox) {
+	for _, child := range b.Mp4BoxFindAll(DataBoxType) {
+		if d, ok := child.(*DataBox); ok {
+			data = append(data, d)
+		}
+	}
+	return
+}
+
+// Mean returns the domain of a freeform item.
+func (b *MetadataItemBox) Mean() string {
+	if mean, ok := b.Mp4BoxFindFirst(MeanBoxType).(*MeanBox); ok {
+		return mean.Mean
+	}
+	return ""
+}
+
+// Name returns the name of a freeform item.
+func (b *MetadataItemBox) Name() string {
+	if name, ok := b.Mp4BoxFindFirst(NameBoxType).(*NameBox); ok {
+		return name.Name
+	}
+	return ""
+}
+
+func (b *MetadataItemBox) Mp4BoxUpdate() uint32 {
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *MetadataItemBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = b.readChildren(r, b.Size-b.HeaderSize()); err != nil {
+		return
+	}
+	return
+}
+
+// readChildren reads the child boxes like Mp4BoxReadChildren, except that
+// ‘data’, ‘mean’ and ‘name’ children are read as DataBox, MeanBox and
+// NameBox.
+func (b *MetadataItemBox) readChildren(r io.Reader, size uint32) (err error) {
+	remainingSize := int64(size)
+	for remainingSize > 0 {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			return
+		}
+		var child Box
+		switch header.Type {
+		case DataBoxType:
+			child = &DataBox{}
+		case MeanBoxType:
+			child = &MeanBox{}
+		case NameBoxType:
+			child = &NameBox{}
+		}
+		if child != nil {
+			if err = child.Mp4BoxRead(r, header); err != nil {
+				return
+			}
+		} else if child, err = ReadBoxAfterHeader(r, header); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *MetadataItemBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
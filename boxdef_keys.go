@@ -0,0 +1,132 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// QuickTime File Format, Metadata, Metadata Item Keys Atom
+
+// Box Type: ‘keys’
+// Container: Meta Box (‘meta’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The KeysBox holds the keys of QuickTime metadata. The items in the
+// ItemListBox of the same meta box refer to the keys by their 1‐based index,
+// which is the box type of the item interpreted as a 32‐bit integer.
+type KeysBox struct {
+	FullHeader
+	NullContainer
+
+	Entries []MetadataKey
+}
+
+type MetadataKey struct {
+	// is the key namespace, usually ‘mdta’ for reverse DNS keys such as
+	// ‘com.apple.quicktime.title’.
+	Namespace FourCC
+
+	Value string
+}
+
+var _ Box = (*KeysBox)(nil)
+
+func init() {
+	BoxRegistry[KeysBoxType] = func() Box { return &KeysBox{} }
+}
+
+func (b KeysBox) Mp4BoxType() BoxType {
+	return KeysBoxType
+}
+
+// Index returns the 1‐based index of the key in the ‘mdta’ namespace, or 0
+// if there is no such key.
+func (b *KeysBox) Index(key string) uint32 {
+	for i, entry := range b.Entries {
+		if entry.Namespace == MdtaFourCC && entry.Value == key {
+			return uint32(i + 1)
+		}
+	}
+	return 0
+}
+
+// Key returns the key of the 1‐based index.
+func (b *KeysBox) Key(index uint32) (key MetadataKey, ok bool) {
+	if index == 0 || index > uint32(len(b.Entries)) {
+		return
+	}
+	return b.Entries[index-1], true
+}
+
+func (b *KeysBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 4 // entry_count
+	for _, entry := range b.Entries {
+		b.Size += 4 // key_size
+		b.Size += 4 // key_namespace
+		b.Size += uint32(len(entry.Value))
+	}
+	return b.Size
+}
+
+func (b *KeysBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	var entryCount uint32
+	if err = binary.Read(r, binary.BigEndian, &entryCount); err != nil {
+		return
+	}
+	remainingSize := int64(b.Size) - int64(b.headerSize()) - 4
+	b.Entries = nil
+	for i := uint32(0); i < entryCount; i++ {
+		var keySize uint32
+		if err = binary.Read(r, binary.BigEndian, &keySize); err != nil {
+			return
+		}
+		remainingSize -= int64(keySize)
+		if keySize < 8 || remainingSize < 0 {
+			err = fmt.Errorf("keys entry has invalid size %d: %w", keySize, ErrInvalidFormat)
+			return
+		}
+		var entry MetadataKey
+		if err = binary.Read(r, binary.BigEndian, &entry.Namespace); err != nil {
+			return
+		}
+		tmp := make([]byte, keySize-8)
+		if _, err = io.ReadFull(r, tmp); err != nil {
+			return
+		}
+		entry.Value = string(tmp)
+		b.Entries = append(b.Entries, entry)
+	}
+	if remainingSize != 0 {
+		err = fmt.Errorf("keys entries do not match box size: %w", ErrInvalidFormat)
+		return
+	}
+	return
+}
+
+func (b *KeysBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(len(b.Entries))); err != nil {
+		return
+	}
+	for _, entry := range b.Entries {
+		if err = binary.Write(w, binary.BigEndian, uint32(8+len(entry.Value))); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, entry.Namespace); err != nil {
+			return
+		}
+		if _, err = io.WriteString(w, entry.Value); err != nil {
+			return
+		}
+	}
+	return
+}
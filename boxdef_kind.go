@@ -0,0 +1,111 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// 8.10.4 Track kind
+
+// Box Type: ‘kind’
+// Container: User Data Box (‘udta’) of the corresponding Track Box (‘trak’)
+// Mandatory: No
+// Quantity: Zero or more
+
+// The KindBox labels a track with its role or kind. It contains a URI, which
+// identifies the naming scheme, optionally followed by a value. If only the
+// URI is present, the role is defined by the URI itself.
+type KindBox struct {
+	FullHeader
+	NullContainer
+
+	SchemeURI NullTerminatedString
+	Value     NullTerminatedString
+}
+
+// Role naming schemes and values commonly found in KindBox.
+const (
+	DASHRoleSchemeURI      = "urn:mpeg:dash:role:2011"
+	DASHRoleMain           = "main"
+	DASHRoleAlternate      = "alternate"
+	DASHRoleSupplementary  = "supplementary"
+	DASHRoleCommentary     = "commentary"
+	DASHRoleDub            = "dub"
+	DASHRoleCaption        = "caption"
+	DASHRoleSubtitle       = "subtitle"
+	DASHRoleForcedSubtitle = "forced-subtitle"
+	DASHRoleDescription    = "description"
+	DASHRoleSign           = "sign"
+	DASHRoleEmergency      = "emergency"
+	DASHRoleEasyReader     = "easyreader"
+	DASHRoleEnhancedAudio  = "enhanced-audio-intelligibility"
+
+	HTMLKindSchemeURI    = "about:html-kind"
+	HTMLKindMain         = "main"
+	HTMLKindAlternative  = "alternative"
+	HTMLKindCaptions     = "captions"
+	HTMLKindSubtitles    = "subtitles"
+	HTMLKindDescriptions = "descriptions"
+	HTMLKindMainDesc     = "main-desc"
+	HTMLKindCommentary   = "commentary"
+	HTMLKindChapters     = "chapters"
+
+	TVAAudioPurposeSchemeURI      = "urn:tva:metadata:cs:AudioPurposeCS:2007"
+	TVAAudioPurposeVisualImpaired = "1"
+	TVAAudioPurposeHardOfHearing  = "2"
+)
+
+var _ Box = (*KindBox)(nil)
+
+func init() {
+	BoxRegistry[KindBoxType] = func() Box { return &KindBox{} }
+}
+
+func (b KindBox) Mp4BoxType() BoxType {
+	return KindBoxType
+}
+
+func (b *KindBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += b.SchemeURI.Size() // string schemeURI;
+	b.Size += b.Value.Size()     // string value;
+	return b.Size
+}
+
+func (b *KindBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize()+1 {
+		err = fmt.Errorf("kind box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	if err = b.SchemeURI.Read(r); err != nil {
+		return
+	}
+	if b.headerSize()+b.SchemeURI.Size() > b.Size {
+		err = fmt.Errorf("kind scheme URI exceeds box boundary: %w", ErrInvalidFormat)
+		return
+	}
+	b.Value = ""
+	if size := b.Size - b.headerSize() - b.SchemeURI.Size(); size > 0 {
+		if err = b.Value.ReadOfSize(r, size); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *KindBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.SchemeURI.Write(w); err != nil {
+		return
+	}
+	if err = b.Value.Write(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 8.10.5 Label box
+
+// Box Type: ‘labl’
+// Container: User Data Box (‘udta’) of a Track Box (‘trak’) or of a Group
+// List Box (‘grpl’) entity group
+// Mandatory: No
+// Quantity: Zero or more
+
+// The LabelBox carries a human readable label of a track or of a group of
+// tracks, in the given language. Labels with the same LabelID are
+// translations of each other.
+type LabelBox struct {
+	FullHeader
+	NullContainer
+
+	// indicates the label applies to the track group or entity group of the
+	// containing box rather than to the track.
+	IsGroupLabel bool
+
+	LabelID uint16
+
+	// is a language tag string as in IETF BCP 47, e.g. ‘en‐US’.
+	Language NullTerminatedString
+
+	Label NullTerminatedString
+}
+
+var _ Box = (*LabelBox)(nil)
+
+func init() {
+	BoxRegistry[LablBoxType] = func() Box { return &LabelBox{} }
+}
+
+func (b LabelBox) Mp4BoxType() BoxType {
+	return LablBoxType
+}
+
+func (b *LabelBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	// unsigned int(1) is_group_label;
+	// unsigned int(15) reserved = 0;
+	b.Size += 2
+	b.Size += 2                 // unsigned int(16) label_id;
+	b.Size += b.Language.Size() // utf8string language;
+	b.Size += b.Label.Size()    // utf8string label;
+	return b.Size
+}
+
+func (b *LabelBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize()+6 {
+		err = fmt.Errorf("labl box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	var tmp [2]uint16
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	b.IsGroupLabel = tmp[0]&0x8000 != 0
+	b.LabelID = tmp[1]
+	if err = b.Language.Read(r); err != nil {
+		return
+	}
+	if b.headerSize()+4+b.Language.Size() >= b.Size {
+		err = fmt.Errorf("labl language exceeds box boundary: %w", ErrInvalidFormat)
+		return
+	}
+	if err = b.Label.ReadOfSize(r, b.Size-b.headerSize()-4-b.Language.Size()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *LabelBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	var flags uint16
+	if b.IsGroupLabel {
+		flags = 0x8000
+	}
+	if err = binary.Write(w, binary.BigEndian, [2]uint16{flags, b.LabelID}); err != nil {
+		return
+	}
+	if err = b.Language.Write(w); err != nil {
+		return
+	}
+	if err = b.Label.Write(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// 8.1.1 Media Data Box
+
+// Box Type: ‘mdat’
+// Container: File
+// Mandatory: No
+// Quantity: Zero or more
+
+// This box contains the media data. In video tracks, this box would contain
+// video frames. A presentation may contain zero or more Media Data Boxes. The
+// actual media data follows the type field; its structure is described by the
+// metadata (see particularly the sample table, subclause 8.5, and the item
+// location box, subclause 8.11.3).
+type MediaDataBox struct {
+	Header
+	NullContainer
+
+	// the contained media data
+	Data []byte
+}
+
+var _ Box = (*MediaDataBox)(nil)
+
+func init() {
+	BoxRegistry[MdatBoxType] = func() Box { return &MediaDataBox{} }
+}
+
+func (b MediaDataBox) Mp4BoxType() BoxType {
+	return MdatBoxType
+}
+
+func (b *MediaDataBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.Data)) // bit(8) data[];
+	return b.Size
+}
+
+func (b *MediaDataBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize() {
+		err = fmt.Errorf("mdat box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	b.Data = make([]byte, b.Size-b.HeaderSize())
+	if _, err = io.ReadFull(r, b.Data); err != nil {
+		return
+	}
+	return
+}
+
+func (b *MediaDataBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = w.Write(b.Data); err != nil {
+		return
+	}
+	return
+}
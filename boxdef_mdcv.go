@@ -0,0 +1,145 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ISO/IEC 23001‐8 / ISO/IEC 14496‐12 12.1.7 Mastering display colour volume
+
+// Box Type: ‘mdcv’
+// Container: Visual Sample Entry
+// Mandatory: No
+// Quantity: Zero or one
+
+// The MasteringDisplayColourVolumeBox describes the colour volume of the
+// display used to master the content. Its fields have the semantics of the
+// mastering display colour volume SEI message of H.265.
+type MasteringDisplayColourVolumeBox struct {
+	Header
+	NullContainer
+
+	// are the chromaticities of the display primaries, in the order green,
+	// blue and red as in the SEI message.
+	DisplayPrimaries [3]MDCVChromaticity
+
+	WhitePoint MDCVChromaticity
+
+	MaxDisplayMasteringLuminance MDCVLuminance
+	MinDisplayMasteringLuminance MDCVLuminance
+}
+
+// Chromaticity is a CIE 1931 xy chromaticity coordinate.
+type Chromaticity struct {
+	X float64
+	Y float64
+}
+
+// MDCVChromaticity is a chromaticity coordinate in increments of 0.00002.
+type MDCVChromaticity struct {
+	X uint16
+	Y uint16
+}
+
+// MDCVLuminance is a luminance in units of 0.0001 cd/m².
+type MDCVLuminance uint32
+
+var _ Box = (*MasteringDisplayColourVolumeBox)(nil)
+
+func init() {
+	BoxRegistry[MdcvBoxType] = func() Box { return &MasteringDisplayColourVolumeBox{} }
+}
+
+func (b MasteringDisplayColourVolumeBox) Mp4BoxType() BoxType {
+	return MdcvBoxType
+}
+
+func NewMDCVChromaticity(c Chromaticity) MDCVChromaticity {
+	return MDCVChromaticity{X: uint16(fixedPoint(c.X, 50000, 0xFFFF)), Y: uint16(fixedPoint(c.Y, 50000, 0xFFFF))}
+}
+
+func (c MDCVChromaticity) Chromaticity() Chromaticity {
+	return Chromaticity{X: float64(c.X) / 50000, Y: float64(c.Y) / 50000}
+}
+
+func NewMDCVLuminance(cdPerM2 float64) MDCVLuminance {
+	return MDCVLuminance(fixedPoint(cdPerM2, 10000, 0xFFFFFFFF))
+}
+
+// fixedPoint returns v in units of 1/scale, rounded and clamped to the range
+// from 0 to max.
+func fixedPoint(v, scale float64, max uint32) uint32 {
+	v = v*scale + 0.5
+	switch {
+	case !(v > 0):
+		return 0
+	case v >= float64(max):
+		return max
+	}
+	return uint32(v)
+}
+
+// CdPerM2 returns the luminance in candelas per square metre.
+func (l MDCVLuminance) CdPerM2() float64 {
+	return float64(l) / 10000
+}
+
+// Primaries returns the red, green and blue display primaries.
+func (b *MasteringDisplayColourVolumeBox) Primaries() (red, green, blue Chromaticity) {
+	return b.DisplayPrimaries[2].Chromaticity(), b.DisplayPrimaries[0].Chromaticity(), b.DisplayPrimaries[1].Chromaticity()
+}
+
+// SetPrimaries sets the display primaries from red, green and blue
+// chromaticities.
+func (b *MasteringDisplayColourVolumeBox) SetPrimaries(red, green, blue Chromaticity) {
+	b.DisplayPrimaries = [3]MDCVChromaticity{NewMDCVChromaticity(green), NewMDCVChromaticity(blue), NewMDCVChromaticity(red)}
+}
+
+func (b *MasteringDisplayColourVolumeBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 2 * 2 * 3 // unsigned int(16) display_primaries_x, display_primaries_y [3];
+	b.Size += 2         // unsigned int(16) white_point_x;
+	b.Size += 2         // unsigned int(16) white_point_y;
+	b.Size += 4         // unsigned int(32) max_display_mastering_luminance;
+	b.Size += 4         // unsigned int(32) min_display_mastering_luminance;
+	return b.Size
+}
+
+func (b *MasteringDisplayColourVolumeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.DisplayPrimaries); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.WhitePoint); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MaxDisplayMasteringLuminance); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.MinDisplayMasteringLuminance); err != nil {
+		return
+	}
+	return
+}
+
+func (b *MasteringDisplayColourVolumeBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.DisplayPrimaries); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.WhitePoint); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MaxDisplayMasteringLuminance); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.MinDisplayMasteringLuminance); err != nil {
+		return
+	}
+	return
+}
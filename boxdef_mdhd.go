@@ -116,11 +116,7 @@ func (b *MediaHeaderBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
 	if err = binary.Read(r, binary.BigEndian, &lang); err != nil {
 		return
 	}
-	if b.Language, err = language.ParseBase(string([]byte{
-		(byte(lang>>10) & 0x1F) + 0x60,
-		(byte(lang>>5) & 0x1F) + 0x60,
-		(byte(lang) & 0x1F) + 0x60,
-	})); err != nil {
+	if b.Language, err = readPackedLanguage(lang); err != nil {
 		return
 	}
 	var tmp uint16
@@ -165,9 +161,7 @@ func (b *MediaHeaderBox) Mp4BoxWrite(w io.Writer) (err error) {
 			return
 		}
 	}
-	iso3 := b.Language.ISO3()
-	lang := (uint16(iso3[0]) << 10) | (uint16(iso3[0]) << 5) | uint16(iso3[0])
-	if err = binary.Write(w, binary.BigEndian, lang); err != nil {
+	if err = binary.Write(w, binary.BigEndian, packLanguage(b.Language)); err != nil {
 		return
 	}
 	if err = binary.Write(w, binary.BigEndian, uint16(0)); err != nil {
@@ -0,0 +1,64 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// QuickTime File Format, Metadata, ‘mean’ Atom
+
+// Box Type: ‘mean’
+// Container: Freeform metadata item (‘----’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The MeanBox holds the reverse DNS domain of a freeform (‘----’) metadata
+// item, e.g. ‘com.apple.iTunes’.
+//
+// The box is only read as a child of a freeform metadata item.
+type MeanBox struct {
+	FullHeader
+	NullContainer
+
+	// fills the box and is not null‐terminated.
+	Mean string
+}
+
+var _ Box = (*MeanBox)(nil)
+
+func (b MeanBox) Mp4BoxType() BoxType {
+	return MeanBoxType
+}
+
+func (b *MeanBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += uint32(len(b.Mean))
+	return b.Size
+}
+
+func (b *MeanBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize() {
+		err = fmt.Errorf("mean box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	tmp := make([]byte, b.Size-b.headerSize())
+	if _, err = io.ReadFull(r, tmp); err != nil {
+		return
+	}
+	b.Mean = string(tmp)
+	return
+}
+
+func (b *MeanBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.Mean); err != nil {
+		return
+	}
+	return
+}
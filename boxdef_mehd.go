@@ -0,0 +1,85 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.8.2 Movie Extends Header Box
+
+// Box Type: ‘mehd’
+// Container: Movie Extends Box(‘mvex’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The Movie Extends Header is optional, and provides the overall duration,
+// including fragments, of a fragmented movie. If this box is not present, the
+// overall duration must be computed by examining each fragment.
+type MovieExtendsHeaderBox struct {
+	FullHeader
+	NullContainer
+
+	// is an integer that declares length of the presentation of the whole
+	// movie including fragments (in the timescale indicated in the Movie
+	// Header Box). The value of this field corresponds to the duration of the
+	// longest track, including movie fragments. If an MP4 file is created in
+	// real‐time, such as used in live streaming, it is not likely that the
+	// fragment_duration is known in advance and this box may be omitted.
+	// Version 1 of the box is needed for values exceeding 32 bits.
+	FragmentDuration uint64
+}
+
+var _ Box = (*MovieExtendsHeaderBox)(nil)
+
+func init() {
+	BoxRegistry[MehdBoxType] = func() Box { return &MovieExtendsHeaderBox{} }
+}
+
+func (b MovieExtendsHeaderBox) Mp4BoxType() BoxType {
+	return MehdBoxType
+}
+
+func (b *MovieExtendsHeaderBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	if b.Version == 1 {
+		b.Size += 8 // unsigned int(64) fragment_duration;
+	} else {
+		b.Size += 4 // unsigned int(32) fragment_duration;
+	}
+	return b.Size
+}
+
+func (b *MovieExtendsHeaderBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Read(r, binary.BigEndian, &b.FragmentDuration); err != nil {
+			return
+		}
+	} else {
+		var tmp uint32
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+			return
+		}
+		b.FragmentDuration = uint64(tmp)
+	}
+	return
+}
+
+func (b *MovieExtendsHeaderBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Write(w, binary.BigEndian, b.FragmentDuration); err != nil {
+			return
+		}
+	} else {
+		if err = binary.Write(w, binary.BigEndian, uint32(b.FragmentDuration)); err != nil {
+			return
+		}
+	}
+	return
+}
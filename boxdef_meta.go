@@ -0,0 +1,122 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// 8.11.1 The Meta box
+
+// Box Type: ‘meta’
+// Container: File, Movie Box (‘moov’), Track Box (‘trak’), Additional Metadata
+// Container Box (‘meco’), Movie Fragment Box (‘moof’), Track Fragment Box
+// (‘traf’) or User Data Box (‘udta’)
+// Mandatory: No
+// Quantity: Zero or one (in File, ‘moov’, and ‘trak’), One or more (in
+// ‘meco’)
+
+// A meta box contains descriptive or annotative metadata. The handler box
+// declares the structure or format of its contents, e.g. ‘mdir’ for an
+// iTunes‐style ItemListBox or ‘mdta’ for QuickTime metadata keyed by the
+// KeysBox.
+//
+// QuickTime defines the box as a plain box without version and flags. The form
+// is detected on read and kept in QuickTime, so the box is written back in the
+// same form.
+type MetaBox struct {
+	FullHeader
+	Container
+
+	// is set if the box is in the QuickTime form without version and flags.
+	QuickTime bool
+}
+
+var _ Box = (*MetaBox)(nil)
+
+func init() {
+	BoxRegistry[MetaBoxType] = func() Box { return &MetaBox{} }
+}
+
+func (b MetaBox) Mp4BoxType() BoxType {
+	return MetaBoxType
+}
+
+func (b *MetaBox) MetaHeaderSize() uint32 {
+	if b.QuickTime {
+		return b.HeaderSize()
+	}
+	return b.headerSize()
+}
+
+// Handler returns the handler box of the meta box, or nil if there is none.
+func (b *MetaBox) Handler() *HandlerBox {
+	hdlr, _ := b.Mp4BoxFindFirst(HdlrBoxType).(*HandlerBox)
+	return hdlr
+}
+
+// ItemList returns the item list box of the meta box, or nil if there is
+// none.
+func (b *MetaBox) ItemList() *ItemListBox {
+	ilst, _ := b.Mp4BoxFindFirst(IlstBoxType).(*ItemListBox)
+	return ilst
+}
+
+// Keys returns the keys box of the meta box, or nil if there is none.
+func (b *MetaBox) Keys() *KeysBox {
+	keys, _ := b.Mp4BoxFindFirst(KeysBoxType).(*KeysBox)
+	return keys
+}
+
+func (b *MetaBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.MetaHeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *MetaBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.Header.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.QuickTime = false
+	if b.Size < b.headerSize() {
+		// too small for version and flags, so it can only be an empty
+		// QuickTime meta box
+		b.QuickTime = true
+		return
+	}
+	var tmp [4]byte
+	if _, err = io.ReadFull(r, tmp[:]); err != nil {
+		return
+	}
+	if binary.BigEndian.Uint32(tmp[:]) != 0 {
+		// an ISO meta box has version and flags 0, so this is the size of
+		// the first child of a QuickTime meta box
+		b.QuickTime = true
+		b.Version = 0
+		b.Flags = [3]uint8{}
+		return b.Mp4BoxReadChildren(io.MultiReader(bytes.NewReader(tmp[:]), r), b.Size-b.HeaderSize())
+	}
+	b.Version = tmp[0]
+	copy(b.Flags[:], tmp[1:])
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.headerSize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *MetaBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if b.QuickTime {
+		err = b.Header.WriteHeader(w)
+	} else {
+		err = b.WriteHeader(w)
+	}
+	if err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
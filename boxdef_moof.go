@@ -67,3 +67,22 @@ func (b *MovieFragmentBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// SequenceNumber returns the sequence number of the movie fragment header, or
+// 0 if there is none.
+func (b *MovieFragmentBox) SequenceNumber() uint32 {
+	if mfhd, ok := b.Mp4BoxFindFirst(MfhdBoxType).(*MovieFragmentHeaderBox); ok {
+		return mfhd.SequenceNumber
+	}
+	return 0
+}
+
+// TrackFragments returns the track fragment boxes in order.
+func (b *MovieFragmentBox) TrackFragments() (trafs []*TrackFragmentBox) {
+	for _, box := range b.Mp4BoxFindAll(TrafBoxType) {
+		if traf, ok := box.(*TrackFragmentBox); ok {
+			trafs = append(trafs, traf)
+		}
+	}
+	return
+}
@@ -55,3 +55,62 @@ func (b *MovieBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// MovieHeader returns the movie header box, or nil if there is none.
+func (b *MovieBox) MovieHeader() *MovieHeaderBox {
+	mvhd, _ := b.Mp4BoxFindFirst(MvhdBoxType).(*MovieHeaderBox)
+	return mvhd
+}
+
+// Tracks returns the track boxes of the movie.
+func (b *MovieBox) Tracks() (tracks []*TrackBox) {
+	for _, box := range b.Mp4BoxFindAll(TrakBoxType) {
+		if trak, ok := box.(*TrackBox); ok {
+			tracks = append(tracks, trak)
+		}
+	}
+	return
+}
+
+// Track returns the track with the given track ID, or nil if there is none.
+func (b *MovieBox) Track(trackID uint32) *TrackBox {
+	for _, trak := range b.Tracks() {
+		if tkhd := trak.TrackHeader(); tkhd != nil && tkhd.TrackID == trackID {
+			return trak
+		}
+	}
+	return nil
+}
+
+// NewTrackID returns an unused track ID, which is the next track ID of the
+// movie header unless that is in use, and updates the next track ID.
+func (b *MovieBox) NewTrackID() (trackID uint32) {
+	mvhd := b.MovieHeader()
+	if mvhd != nil && mvhd.NextTrackID != 0 && mvhd.NextTrackID != 0xFFFFFFFF && b.Track(mvhd.NextTrackID) == nil {
+		trackID = mvhd.NextTrackID
+	} else {
+		for _, trak := range b.Tracks() {
+			if tkhd := trak.TrackHeader(); tkhd != nil && tkhd.TrackID > trackID {
+				trackID = tkhd.TrackID
+			}
+		}
+		trackID++
+	}
+	if mvhd != nil {
+		mvhd.NextTrackID = trackID + 1
+	}
+	return
+}
+
+// TrackExtends returns the track extends box of the track with the given
+// track ID, or nil if there is none.
+func (b *MovieBox) TrackExtends(trackID uint32) *TrackExtendsBox {
+	if mvex, ok := b.Mp4BoxFindFirst(MvexBoxType).(*MovieExtendsBox); ok {
+		for _, box := range mvex.Mp4BoxFindAll(TrexBoxType) {
+			if trex, ok := box.(*TrackExtendsBox); ok && trex.TrackID == trackID {
+				return trex
+			}
+		}
+	}
+	return nil
+}
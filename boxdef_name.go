@@ -0,0 +1,64 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// QuickTime File Format, Metadata, ‘name’ Atom
+
+// Box Type: ‘name’
+// Container: Freeform metadata item (‘----’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The NameBox holds the name of a freeform (‘----’) metadata item within the
+// domain given by the MeanBox.
+//
+// The box is only read as a child of a freeform metadata item.
+type NameBox struct {
+	FullHeader
+	NullContainer
+
+	// fills the box and is not null‐terminated.
+	Name string
+}
+
+var _ Box = (*NameBox)(nil)
+
+func (b NameBox) Mp4BoxType() BoxType {
+	return NameBoxType
+}
+
+func (b *NameBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += uint32(len(b.Name))
+	return b.Size
+}
+
+func (b *NameBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize() {
+		err = fmt.Errorf("name box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	tmp := make([]byte, b.Size-b.headerSize())
+	if _, err = io.ReadFull(r, tmp); err != nil {
+		return
+	}
+	b.Name = string(tmp)
+	return
+}
+
+func (b *NameBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.Name); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 Cue Payload Box
+
+// Box Types: ‘payl’
+// Container: WebVTT Cue Box (‘vttc’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The CuePayloadBox contains the text of the cue, in the WebVTT cue text
+// syntax.
+type CuePayloadBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	CuePayload string
+}
+
+var _ Box = (*CuePayloadBox)(nil)
+
+func init() {
+	BoxRegistry[PaylBoxType] = func() Box { return &CuePayloadBox{} }
+}
+
+func (b CuePayloadBox) Mp4BoxType() BoxType {
+	return PaylBoxType
+}
+
+func (b *CuePayloadBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.CuePayload)) // boxstring cue_text;
+	return b.Size
+}
+
+func (b *CuePayloadBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.CuePayload, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *CuePayloadBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.CuePayload); err != nil {
+		return
+	}
+	return
+}
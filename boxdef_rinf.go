@@ -0,0 +1,65 @@
+package mp4
+
+import (
+	"io"
+)
+
+// 8.15.3 Restricted Scheme Information Box
+
+// Box Types: ‘rinf’
+// Container: Restricted Sample Entry or Sample Entry
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The Restricted Scheme Information Box contains all the information required
+// both to understand the restriction scheme applied and its parameters. It
+// also documents the original (untransformed) sample entry type of the media.
+// The Restricted Scheme Information Box is a container Box. It is mandatory in
+// a sample entry that uses a code indicating a restricted stream, i.e.,
+// ‘resv’.
+//
+// When used in a restricted sample entry, this box must contain the original
+// format box to document the original sample entry type and a scheme type
+// box. A scheme information box may be required depending on the restriction
+// scheme.
+type RestrictedSchemeInfoBox struct {
+	Header
+	Container
+}
+
+var _ Box = (*RestrictedSchemeInfoBox)(nil)
+
+func init() {
+	BoxRegistry[RinfBoxType] = func() Box { return &RestrictedSchemeInfoBox{} }
+}
+
+func (b RestrictedSchemeInfoBox) Mp4BoxType() BoxType {
+	return RinfBoxType
+}
+
+func (b *RestrictedSchemeInfoBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *RestrictedSchemeInfoBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.HeaderSize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *RestrictedSchemeInfoBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
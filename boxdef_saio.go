@@ -0,0 +1,138 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.7.9 Sample Auxiliary Information Offsets Box
+
+// Box Type: ‘saio’
+// Container: Sample Table Box (‘stbl’) or Track Fragment Box ('traf')
+// Mandatory: No
+// Quantity: Zero or More
+
+// Gives the position information for the sample auxiliary information, in a
+// way similar to the chunk offsets for sample data.
+//
+// If the aux_info_type is omitted, it is implied as for the Sample Auxiliary
+// Information Sizes Box.
+//
+// In a Track Fragment Box, the offsets are relative to the same base offset
+// as the data offsets of the track runs, which is the start of the enclosing
+// Movie Fragment Box if the default‐base‐is‐moof flag is set in the track
+// fragment header. If there is a single offset, the auxiliary information of
+// all samples of the track fragment is stored contiguously, in the order of
+// the samples.
+type SampleAuxiliaryInformationOffsetsBox struct {
+	FullHeader
+	NullContainer
+
+	// is an integer that identifies the type of the sample auxiliary
+	// information. It is only present if FLAG_SAIO_AUX_INFO_TYPE is set.
+	AuxInfoType FourCC
+
+	// is an integer that identifies the “stream” of sample auxiliary
+	// information having the same value of aux_info_type and associated to the
+	// same track.
+	AuxInfoTypeParameter uint32
+
+	// gives the position in the file of the sample auxiliary information, for
+	// each chunk or track fragment run. Version 1 of the box is needed for
+	// offsets exceeding 32 bits.
+	Offsets []uint64
+}
+
+const (
+	// Indicates the presence of the aux_info_type and aux_info_type_parameter
+	// fields.
+	FLAG_SAIO_AUX_INFO_TYPE uint32 = 0x01
+)
+
+var _ Box = (*SampleAuxiliaryInformationOffsetsBox)(nil)
+
+func init() {
+	BoxRegistry[SaioBoxType] = func() Box { return &SampleAuxiliaryInformationOffsetsBox{} }
+}
+
+func (b SampleAuxiliaryInformationOffsetsBox) Mp4BoxType() BoxType {
+	return SaioBoxType
+}
+
+func (b *SampleAuxiliaryInformationOffsetsBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	if b.Mp4BoxFlags()&FLAG_SAIO_AUX_INFO_TYPE > 0 {
+		b.Size += 4 // unsigned int(32) aux_info_type;
+		b.Size += 4 // unsigned int(32) aux_info_type_parameter;
+	}
+	b.Size += 4 // unsigned int(32) entry_count;
+	if b.Version == 0 {
+		b.Size += 4 * uint32(len(b.Offsets)) // unsigned int(32) offset[ entry_count ];
+	} else {
+		b.Size += 8 * uint32(len(b.Offsets)) // unsigned int(64) offset[ entry_count ];
+	}
+	return b.Size
+}
+
+func (b *SampleAuxiliaryInformationOffsetsBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Mp4BoxFlags()&FLAG_SAIO_AUX_INFO_TYPE > 0 {
+		if err = binary.Read(r, binary.BigEndian, &b.AuxInfoType); err != nil {
+			return
+		}
+		if err = binary.Read(r, binary.BigEndian, &b.AuxInfoTypeParameter); err != nil {
+			return
+		}
+	}
+	var entryCount uint32
+	if err = binary.Read(r, binary.BigEndian, &entryCount); err != nil {
+		return
+	}
+	b.Offsets = make([]uint64, entryCount)
+	for i := range b.Offsets {
+		if b.Version == 0 {
+			var tmp uint32
+			if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+				return
+			}
+			b.Offsets[i] = uint64(tmp)
+		} else {
+			if err = binary.Read(r, binary.BigEndian, &b.Offsets[i]); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (b *SampleAuxiliaryInformationOffsetsBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if b.Mp4BoxFlags()&FLAG_SAIO_AUX_INFO_TYPE > 0 {
+		if err = binary.Write(w, binary.BigEndian, b.AuxInfoType); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, b.AuxInfoTypeParameter); err != nil {
+			return
+		}
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(len(b.Offsets))); err != nil {
+		return
+	}
+	for _, offset := range b.Offsets {
+		if b.Version == 0 {
+			if err = binary.Write(w, binary.BigEndian, uint32(offset)); err != nil {
+				return
+			}
+		} else {
+			if err = binary.Write(w, binary.BigEndian, offset); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
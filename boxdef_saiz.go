@@ -0,0 +1,147 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.7.8 Sample Auxiliary Information Sizes Box
+
+// Box Type: ‘saiz’
+// Container: Sample Table Box (‘stbl’) or Track Fragment Box ('traf')
+// Mandatory: No
+// Quantity: Zero or More
+
+// This box contains the sample‐specific size of the auxiliary information
+// stored for the samples. Per‐sample auxiliary information is stored as
+// auxiliary information of type aux_info_type and aux_info_type_parameter,
+// which is located by the Sample Auxiliary Information Offsets Box with the
+// same type and parameter.
+//
+// If the aux_info_type is omitted, it is implied from the scheme type of the
+// protection scheme information of the sample entry, or from the sample entry
+// type otherwise.
+type SampleAuxiliaryInformationSizesBox struct {
+	FullHeader
+	NullContainer
+
+	// is an integer that identifies the type of the sample auxiliary
+	// information. It is only present if FLAG_SAIZ_AUX_INFO_TYPE is set.
+	AuxInfoType FourCC
+
+	// is an integer that identifies the “stream” of sample auxiliary
+	// information having the same value of aux_info_type and associated to the
+	// same track.
+	AuxInfoTypeParameter uint32
+
+	// is an integer specifying the sample auxiliary information size for the
+	// case where all the indicated samples have the same sample auxiliary
+	// information size. If the size varies then this field shall be zero.
+	DefaultSampleInfoSize uint8
+
+	// is an integer that gives the number of samples for which a size is
+	// defined. It is only used if DefaultSampleInfoSize is not 0; otherwise
+	// the number of sizes is the sample count.
+	SampleCount uint32
+
+	// gives the size of the sample auxiliary information in bytes. This may be
+	// zero to indicate samples with no associated auxiliary information.
+	SampleInfoSizes []uint8
+}
+
+const (
+	// Indicates the presence of the aux_info_type and aux_info_type_parameter
+	// fields.
+	FLAG_SAIZ_AUX_INFO_TYPE uint32 = 0x01
+)
+
+var _ Box = (*SampleAuxiliaryInformationSizesBox)(nil)
+
+func init() {
+	BoxRegistry[SaizBoxType] = func() Box { return &SampleAuxiliaryInformationSizesBox{} }
+}
+
+func (b SampleAuxiliaryInformationSizesBox) Mp4BoxType() BoxType {
+	return SaizBoxType
+}
+
+func (b *SampleAuxiliaryInformationSizesBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	if b.Mp4BoxFlags()&FLAG_SAIZ_AUX_INFO_TYPE > 0 {
+		b.Size += 4 // unsigned int(32) aux_info_type;
+		b.Size += 4 // unsigned int(32) aux_info_type_parameter;
+	}
+	b.Size += 1 // unsigned int(8) default_sample_info_size;
+	b.Size += 4 // unsigned int(32) sample_count;
+	if b.DefaultSampleInfoSize == 0 {
+		b.Size += uint32(len(b.SampleInfoSizes)) // unsigned int(8) sample_info_size[ sample_count ];
+	}
+	return b.Size
+}
+
+func (b *SampleAuxiliaryInformationSizesBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Mp4BoxFlags()&FLAG_SAIZ_AUX_INFO_TYPE > 0 {
+		if err = binary.Read(r, binary.BigEndian, &b.AuxInfoType); err != nil {
+			return
+		}
+		if err = binary.Read(r, binary.BigEndian, &b.AuxInfoTypeParameter); err != nil {
+			return
+		}
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.DefaultSampleInfoSize); err != nil {
+		return
+	}
+	var sampleCount uint32
+	if err = binary.Read(r, binary.BigEndian, &sampleCount); err != nil {
+		return
+	}
+	b.SampleCount = 0
+	b.SampleInfoSizes = nil
+	if b.DefaultSampleInfoSize != 0 {
+		b.SampleCount = sampleCount
+	} else {
+		b.SampleInfoSizes = make([]uint8, sampleCount)
+		if _, err = io.ReadFull(r, b.SampleInfoSizes); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *SampleAuxiliaryInformationSizesBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if b.Mp4BoxFlags()&FLAG_SAIZ_AUX_INFO_TYPE > 0 {
+		if err = binary.Write(w, binary.BigEndian, b.AuxInfoType); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, b.AuxInfoTypeParameter); err != nil {
+			return
+		}
+	}
+	if err = binary.Write(w, binary.BigEndian, b.DefaultSampleInfoSize); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.NumSamples()); err != nil {
+		return
+	}
+	if b.DefaultSampleInfoSize == 0 {
+		if _, err = w.Write(b.SampleInfoSizes); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// NumSamples returns the number of samples for which a size is defined.
+func (b *SampleAuxiliaryInformationSizesBox) NumSamples() uint32 {
+	if b.DefaultSampleInfoSize != 0 {
+		return b.SampleCount
+	}
+	return uint32(len(b.SampleInfoSizes))
+}
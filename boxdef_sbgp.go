@@ -0,0 +1,123 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.9.2 Sample to Group Box
+
+// Box Type: ‘sbgp’
+// Container: Sample Table Box (‘stbl’) or Track Fragment Box (‘traf’)
+// Mandatory: No
+// Quantity: Zero or more
+
+// This table can be used to find the group that a sample belongs to and the
+// associated description of that sample group. The table is compactly coded
+// with each entry giving the index of the first sample of a run of samples
+// with the same sample group descriptor. The sample group description ID is an
+// index that refers to a SampleGroupDescription box, which contains entries
+// describing the characteristics of each sample group.
+//
+// There may be multiple instances of this box if there is more than one sample
+// grouping for the samples in a track. Each instance of the SampleToGroup box
+// has a type code that distinguishes different sample groupings.
+type SampleToGroupBox struct {
+	FullHeader
+	NullContainer
+
+	// is an integer that identifies the type (i.e. criterion used to form the
+	// sample groups) of the sample grouping and links it to its sample group
+	// description table with the same value for grouping type.
+	GroupingType FourCC
+
+	// is an indication of the sub‐type of the grouping. It is only present in
+	// version 1 of the box.
+	GroupingTypeParameter uint32
+
+	Entries []SampleToGroupEntry
+}
+
+var _ Box = (*SampleToGroupBox)(nil)
+
+func init() {
+	BoxRegistry[SbgpBoxType] = func() Box { return &SampleToGroupBox{} }
+}
+
+type SampleToGroupEntry struct {
+	// is an integer that gives the number of consecutive samples with the
+	// same sample group descriptor.
+	SampleCount uint32
+
+	// is an integer that gives the index of the sample group entry which
+	// describes the samples in this group. The index ranges from 1 to the
+	// number of sample group entries in the SampleGroupDescription Box, or
+	// takes the value 0 to indicate that this sample is a member of no group
+	// of this type. In a track fragment, indices from 0x10001 refer to the
+	// SampleGroupDescription Box of the track fragment.
+	GroupDescriptionIndex uint32
+}
+
+func (b SampleToGroupBox) Mp4BoxType() BoxType {
+	return SbgpBoxType
+}
+
+func (b *SampleToGroupBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 4 // unsigned int(32) grouping_type;
+	if b.Version == 1 {
+		b.Size += 4 // unsigned int(32) grouping_type_parameter;
+	}
+	b.Size += 4 // unsigned int(32) entry_count;
+	// for (i=1; i <= entry_count; i++) {
+	//     unsigned int(32) sample_count;
+	//     unsigned int(32) group_description_index;
+	// }
+	b.Size += 8 * uint32(len(b.Entries))
+	return b.Size
+}
+
+func (b *SampleToGroupBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.GroupingType); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Read(r, binary.BigEndian, &b.GroupingTypeParameter); err != nil {
+			return
+		}
+	}
+	var entryCount uint32
+	if err = binary.Read(r, binary.BigEndian, &entryCount); err != nil {
+		return
+	}
+	b.Entries = make([]SampleToGroupEntry, entryCount)
+	if err = binary.Read(r, binary.BigEndian, b.Entries); err != nil {
+		return
+	}
+	return
+}
+
+func (b *SampleToGroupBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.GroupingType); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Write(w, binary.BigEndian, b.GroupingTypeParameter); err != nil {
+			return
+		}
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(len(b.Entries))); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Entries); err != nil {
+		return
+	}
+	return
+}
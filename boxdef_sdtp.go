@@ -0,0 +1,115 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.6.4 Independent and Disposable Samples Box
+
+// Box Types: ‘sdtp’
+// Container: Sample Table Box (‘stbl’) or Track Fragment Box (‘traf’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// This optional table answers three questions about sample dependency:
+//
+//     1) does this sample depend on others (is it an I‐picture)?
+//     2) do no other samples depend on this one?
+//     3) does this sample contain multiple (redundant) encodings of the data at
+//        this time‐instant (possibly with different dependencies)?
+//
+// In the absence of this table:
+//
+//     1) the sync sample table answers the first question; in most video codecs,
+//        I‐pictures are also sync points,
+//     2) the dependency of other samples on this one is unknown.
+//     3) the existence of redundant coding is unknown.
+//
+// When performing ‘trick’ modes, such as fast‐forward, it is possible to use
+// the first piece of information to locate independently decodable samples.
+// Similarly, when performing random access, it may be necessary to locate the
+// previous sync point or random access recovery point, and roll‐forward from
+// the sync point or the pre‐roll starting point of the random access recovery
+// point to the desired point. While rolling forward, samples on which no others
+// depend need not be retrieved or decoded.
+//
+// The size of the table, sample_count, is taken from the sample_count in the
+// Sample Size Box ('stsz') or Compact Sample Size Box (‘stz2’).
+type SampleDependencyTypeBox struct {
+	FullHeader
+	NullContainer
+	Entries []SampleDependencyTypeEntry
+}
+
+var _ Box = (*SampleDependencyTypeBox)(nil)
+
+func init() {
+	BoxRegistry[SdtpBoxType] = func() Box { return &SampleDependencyTypeBox{} }
+}
+
+type SampleDependencyTypeEntry struct {
+	IsLeading           SampleIsLeading
+	SampleDependsOn     SampleDependsOn
+	SampleIsDependedOn  SampleIsDependedOn
+	SampleHasRedundancy SampleHasRedundancy
+}
+
+func (e SampleDependencyTypeEntry) encode() uint8 {
+	return uint8(e.IsLeading&0b11)<<6 | uint8(e.SampleDependsOn&0b11)<<4 | uint8(e.SampleIsDependedOn&0b11)<<2 | uint8(e.SampleHasRedundancy&0b11)
+}
+
+func decodeSampleDependencyTypeEntry(v uint8) SampleDependencyTypeEntry {
+	return SampleDependencyTypeEntry{
+		IsLeading:           SampleIsLeading(v >> 6),
+		SampleDependsOn:     SampleDependsOn((v >> 4) & 0b11),
+		SampleIsDependedOn:  SampleIsDependedOn((v >> 2) & 0b11),
+		SampleHasRedundancy: SampleHasRedundancy(v & 0b11),
+	}
+}
+
+func (b SampleDependencyTypeBox) Mp4BoxType() BoxType {
+	return SdtpBoxType
+}
+
+func (b *SampleDependencyTypeBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	// for (i=0; i < sample_count; i++){
+	//     unsigned int(2) is_leading;
+	//     unsigned int(2) sample_depends_on;
+	//     unsigned int(2) sample_is_depended_on;
+	//     unsigned int(2) sample_has_redundancy;
+	// }
+	b.Size += uint32(len(b.Entries))
+	return b.Size
+}
+
+func (b *SampleDependencyTypeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	tmp := make([]uint8, b.Size-b.headerSize())
+	if _, err = io.ReadFull(r, tmp); err != nil {
+		return
+	}
+	b.Entries = make([]SampleDependencyTypeEntry, len(tmp))
+	for i, v := range tmp {
+		b.Entries[i] = decodeSampleDependencyTypeEntry(v)
+	}
+	return
+}
+
+func (b *SampleDependencyTypeBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	tmp := make([]uint8, len(b.Entries))
+	for i, entry := range b.Entries {
+		tmp[i] = entry.encode()
+	}
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	return
+}
@@ -145,16 +145,16 @@ func (b *SampleEncryptionBox) Mp4BoxUpdate() uint32 {
 	b.UserType = b.Mp4BoxUserType()
 	b.Size = b.headerSize()
 	flags := b.Mp4BoxFlags()
-	ivSize := PiffIVSize(8)
 	if flags&FLAG_SENC_OVERRIDE_TRACK_ENCRYPTION_BOX_PARAMS > 0 {
 		// unsigned int(24) AlgorithmID;
 		// unsigned int(8) IV_size;
 		// unsigned int(8)[16] KID;
 		b.Size += 3 + 1 + 16
-		ivSize = b.IVSize
 	}
-	b.Size += 4                                       // unsigned int(32) sample_count;
-	b.Size += uint32(ivSize) * uint32(len(b.Samples)) // unsigned int(Per_Sample_IV_Size*8) InitializationVector;
+	b.Size += 4 // unsigned int(32) sample_count;
+	for _, sample := range b.Samples {
+		b.Size += uint32(len(sample.InitializationVector)) // unsigned int(Per_Sample_IV_Size*8) InitializationVector;
+	}
 	if flags&FLAG_SENC_USE_SUBSAMPLE_ENCRYPTION > 0 {
 		b.Size += 2 * uint32(len(b.Samples)) // unsigned int(16) subsample_count;
 		var subsampleTotal uint32
@@ -0,0 +1,48 @@
+package mp4
+
+import (
+	"io"
+)
+
+// 12.6.2 Subtitle media header
+
+// Box Types: ‘sthd’
+// Container: Media Information Box (‘minf’)
+// Mandatory: Yes
+// Quantity: Exactly one specific media header shall be present
+
+// Subtitle tracks use the SubtitleMediaHeaderBox in the media information box.
+type SubtitleMediaHeaderBox struct {
+	FullHeader
+	NullContainer
+}
+
+var _ Box = (*SubtitleMediaHeaderBox)(nil)
+
+func init() {
+	BoxRegistry[SthdBoxType] = func() Box { return &SubtitleMediaHeaderBox{} }
+}
+
+func (b SubtitleMediaHeaderBox) Mp4BoxType() BoxType {
+	return SthdBoxType
+}
+
+func (b *SubtitleMediaHeaderBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	return b.Size
+}
+
+func (b *SubtitleMediaHeaderBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	return
+}
+
+func (b *SubtitleMediaHeaderBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,131 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 12.6.3.2 Subtitle Sample entry
+
+// Box Types: ‘stpp’
+// Container: Sample Description Box (‘stsd’)
+// Mandatory: Yes
+// Quantity: One or more
+
+// XML based subtitle tracks, such as TTML, use the XMLSubtitleSampleEntryBox.
+//
+// namespace is one or more white‐space separated namespaces the sample
+// documents conform to. schema_location is zero or more white‐space separated
+// URL(s) for the schema(s) of the namespaces. auxiliary_mime_types lists the
+// media type of all auxiliary resources, such as images and fonts, stored as
+// subsamples.
+//
+// auxiliary_mime_types is optional and may be omitted entirely rather than
+// stored as an empty string. It is treated as absent if the data following
+// schema_location starts with a box header.
+type XMLSubtitleSampleEntryBox struct {
+	SampleEntry
+
+	Namespace      NullTerminatedString
+	SchemaLocation NullTerminatedString
+
+	HasAuxiliaryMIMETypes bool
+	AuxiliaryMIMETypes    NullTerminatedString
+}
+
+var _ Box = (*XMLSubtitleSampleEntryBox)(nil)
+
+func init() {
+	BoxRegistry[StppBoxType] = func() Box { return &XMLSubtitleSampleEntryBox{} }
+}
+
+func (b *XMLSubtitleSampleEntryBox) XMLSubtitleSampleEntrySize() (size uint32) {
+	size = b.SampleEntrySize()
+	size += b.Namespace.Size()      // string namespace;
+	size += b.SchemaLocation.Size() // string schema_location; // optional
+	if b.HasAuxiliaryMIMETypes {
+		size += b.AuxiliaryMIMETypes.Size() // string auxiliary_mime_types; // optional
+	}
+	return
+}
+
+func (b *XMLSubtitleSampleEntryBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.XMLSubtitleSampleEntrySize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *XMLSubtitleSampleEntryBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.SampleEntry.Mp4BoxRead(r, header); err != nil {
+		return
+	}
+	if b.Size < b.SampleEntrySize() {
+		err = fmt.Errorf("stpp box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	data := make([]byte, b.Size-b.SampleEntrySize())
+	if _, err = io.ReadFull(r, data); err != nil {
+		return
+	}
+	br := bytes.NewReader(data)
+	if err = b.Namespace.Read(br); err != nil {
+		return
+	}
+	if err = b.SchemaLocation.Read(br); err != nil {
+		return
+	}
+	b.HasAuxiliaryMIMETypes = false
+	b.AuxiliaryMIMETypes = ""
+	if rest := data[len(data)-br.Len():]; len(rest) > 0 && !looksLikeBoxHeader(rest) {
+		b.HasAuxiliaryMIMETypes = true
+		if err = b.AuxiliaryMIMETypes.Read(br); err != nil {
+			return
+		}
+	}
+	if err = b.Mp4BoxReadChildren(br, uint32(br.Len())); err != nil {
+		return
+	}
+	return
+}
+
+func (b *XMLSubtitleSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.SampleEntry.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = b.Namespace.Write(w); err != nil {
+		return
+	}
+	if err = b.SchemaLocation.Write(w); err != nil {
+		return
+	}
+	if b.HasAuxiliaryMIMETypes {
+		if err = b.AuxiliaryMIMETypes.Write(w); err != nil {
+			return
+		}
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
+
+// looksLikeBoxHeader reports whether data starts with a plausible compact box
+// header fitting into data.
+func looksLikeBoxHeader(data []byte) bool {
+	if len(data) < 8 {
+		return false
+	}
+	size := binary.BigEndian.Uint32(data)
+	if size < 8 || size > uint32(len(data)) {
+		return false
+	}
+	for _, c := range data[4:8] {
+		if c < 0x20 || c > 0x7E {
+			return false
+		}
+	}
+	return true
+}
@@ -32,7 +32,13 @@ type SampleSizeBox struct {
 	// the sample size table. If this field is not 0, it specifies the constant
 	// sample size, and no array follows.
 	SampleSize uint32
-	Entries    []SampleSizeEntry
+
+	// is an integer that gives the number of samples in the track. It is only
+	// used if SampleSize is not 0; otherwise the number of entries is the
+	// sample count.
+	SampleCount uint32
+
+	Entries []SampleSizeEntry
 }
 
 var _ Box = (*SampleSizeBox)(nil)
@@ -44,14 +50,6 @@ func init() {
 type SampleSizeEntry struct {
 	// is an integer specifying the size of a sample, indexed by its number.
 	EntrySize uint32
-
-	// is an integer that gives the number of samples in each of these chunks
-	SamplesPerChunk uint32
-
-	// is an integer that gives the index of the sample entry that describes the
-	// samples in this chunk. The index ranges from 1 to the number of sample
-	// entries in the Sample Description Box
-	SampleDescrptionIndex uint32
 }
 
 func (b SampleSizeBox) Mp4BoxType() BoxType {
@@ -85,7 +83,11 @@ func (b *SampleSizeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
 	if err = binary.Read(r, binary.BigEndian, &sampleCount); err != nil {
 		return
 	}
-	if b.SampleSize == 0 {
+	b.SampleCount = 0
+	b.Entries = nil
+	if b.SampleSize != 0 {
+		b.SampleCount = sampleCount
+	} else {
 		b.Entries = make([]SampleSizeEntry, sampleCount)
 		if err = binary.Read(r, binary.BigEndian, b.Entries); err != nil {
 			return
@@ -101,7 +103,7 @@ func (b *SampleSizeBox) Mp4BoxWrite(w io.Writer) (err error) {
 	if err = binary.Write(w, binary.BigEndian, b.SampleSize); err != nil {
 		return
 	}
-	if err = binary.Write(w, binary.BigEndian, uint32(len(b.Entries))); err != nil {
+	if err = binary.Write(w, binary.BigEndian, b.NumSamples()); err != nil {
 		return
 	}
 	if b.SampleSize == 0 {
@@ -111,3 +113,20 @@ func (b *SampleSizeBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// NumSamples returns the number of samples in the track.
+func (b *SampleSizeBox) NumSamples() uint32 {
+	if b.SampleSize != 0 {
+		return b.SampleCount
+	}
+	return uint32(len(b.Entries))
+}
+
+// SampleSizeAt returns the size of the sample with the given zero‐based index.
+// The index must be less than NumSamples.
+func (b *SampleSizeBox) SampleSizeAt(index uint32) uint32 {
+	if b.SampleSize != 0 {
+		return b.SampleSize
+	}
+	return b.Entries[index].EntrySize
+}
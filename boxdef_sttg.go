@@ -0,0 +1,57 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 Cue Settings Box
+
+// Box Types: ‘sttg’
+// Container: WebVTT Cue Box (‘vttc’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The CueSettingsBox contains the cue settings of the cue timing line, such
+// as position and alignment.
+type CueSettingsBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	Settings string
+}
+
+var _ Box = (*CueSettingsBox)(nil)
+
+func init() {
+	BoxRegistry[SttgBoxType] = func() Box { return &CueSettingsBox{} }
+}
+
+func (b CueSettingsBox) Mp4BoxType() BoxType {
+	return SttgBoxType
+}
+
+func (b *CueSettingsBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.Settings)) // boxstring settings;
+	return b.Size
+}
+
+func (b *CueSettingsBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.Settings, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *CueSettingsBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.Settings); err != nil {
+		return
+	}
+	return
+}
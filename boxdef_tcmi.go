@@ -0,0 +1,111 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// QuickTime File Format Timecode Media Information
+
+// Box Type: ‘tcmi’
+// Container: Timecode Media Box (‘tmcd’) in the Base Media Information Header
+// Box (‘gmhd’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The TimecodeMediaInformationBox defines how the timecode is displayed when
+// the timecode track is shown.
+type TimecodeMediaInformationBox struct {
+	FullHeader
+	NullContainer
+
+	TextFont        int16
+	TextFace        uint16
+	TextSize        uint16
+	TextColor       [3]uint16
+	BackgroundColor [3]uint16
+
+	// is stored as a Pascal string of at most 255 bytes.
+	FontName string
+}
+
+var _ Box = (*TimecodeMediaInformationBox)(nil)
+
+func init() {
+	BoxRegistry[TcmiBoxType] = func() Box { return &TimecodeMediaInformationBox{} }
+}
+
+func (b TimecodeMediaInformationBox) Mp4BoxType() BoxType {
+	return TcmiBoxType
+}
+
+func (b *TimecodeMediaInformationBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 2                           // int(16) text_font;
+	b.Size += 2                           // unsigned int(16) text_face;
+	b.Size += 2                           // unsigned int(16) text_size;
+	b.Size += 2                           // const unsigned int(16) reserved = 0;
+	b.Size += 2 * 3                       // unsigned int(16)[3] text_color;
+	b.Size += 2 * 3                       // unsigned int(16)[3] background_color;
+	b.Size += 1 + uint32(len(b.FontName)) // pstring font_name;
+	return b.Size
+}
+
+func (b *TimecodeMediaInformationBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize()+20 {
+		err = fmt.Errorf("tcmi box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	var tmp [10]uint16
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	b.TextFont = int16(tmp[0])
+	b.TextFace = tmp[1]
+	b.TextSize = tmp[2]
+	copy(b.TextColor[:], tmp[4:7])
+	copy(b.BackgroundColor[:], tmp[7:10])
+	b.FontName = ""
+	remaining := b.Size - b.headerSize() - 20
+	if remaining == 0 {
+		return
+	}
+	data := make([]byte, remaining)
+	if _, err = io.ReadFull(r, data); err != nil {
+		return
+	}
+	if int(data[0]) > len(data)-1 {
+		err = fmt.Errorf("tcmi font name length exceeds box: %w", ErrInvalidFormat)
+		return
+	}
+	b.FontName = string(data[1 : 1+data[0]])
+	return
+}
+
+func (b *TimecodeMediaInformationBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if len(b.FontName) > 0xFF {
+		err = fmt.Errorf("tcmi font name exceeds 255 bytes: %w", ErrInvalidFormat)
+		return
+	}
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	tmp := [10]uint16{uint16(b.TextFont), b.TextFace, b.TextSize, 0}
+	copy(tmp[4:7], b.TextColor[:])
+	copy(tmp[7:10], b.BackgroundColor[:])
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint8(len(b.FontName))); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.FontName); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,86 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 8.8.12 Track fragment decode time
+
+// Box Type: ‘tfdt’
+// Container: Track Fragment box (‘traf’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The Track Fragment Base Media Decode Time Box provides the absolute decode
+// time, measured on the media timeline, of the first sample in decode order in
+// the track fragment. This can be useful, for example, when performing random
+// access in a file; it is not necessary to sum the sample durations of all
+// preceding samples in previous fragments to find this value (where the sample
+// durations are the deltas in the Decoding Time to Sample Box and the
+// sample_durations in the preceding track runs).
+type TrackFragmentBaseMediaDecodeTimeBox struct {
+	FullHeader
+	NullContainer
+
+	// is an integer equal to the sum of the decode durations of all earlier
+	// samples in the media, expressed in the media's timescale. It does not
+	// include the samples added in the enclosing track fragment. Version 1 of
+	// the box is needed for values exceeding 32 bits.
+	BaseMediaDecodeTime uint64
+}
+
+var _ Box = (*TrackFragmentBaseMediaDecodeTimeBox)(nil)
+
+func init() {
+	BoxRegistry[TfdtBoxType] = func() Box { return &TrackFragmentBaseMediaDecodeTimeBox{} }
+}
+
+func (b TrackFragmentBaseMediaDecodeTimeBox) Mp4BoxType() BoxType {
+	return TfdtBoxType
+}
+
+func (b *TrackFragmentBaseMediaDecodeTimeBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	if b.Version == 1 {
+		b.Size += 8 // unsigned int(64) baseMediaDecodeTime;
+	} else {
+		b.Size += 4 // unsigned int(32) baseMediaDecodeTime;
+	}
+	return b.Size
+}
+
+func (b *TrackFragmentBaseMediaDecodeTimeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Read(r, binary.BigEndian, &b.BaseMediaDecodeTime); err != nil {
+			return
+		}
+	} else {
+		var tmp uint32
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+			return
+		}
+		b.BaseMediaDecodeTime = uint64(tmp)
+	}
+	return
+}
+
+func (b *TrackFragmentBaseMediaDecodeTimeBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if b.Version == 1 {
+		if err = binary.Write(w, binary.BigEndian, b.BaseMediaDecodeTime); err != nil {
+			return
+		}
+	} else {
+		if err = binary.Write(w, binary.BigEndian, uint32(b.BaseMediaDecodeTime)); err != nil {
+			return
+		}
+	}
+	return
+}
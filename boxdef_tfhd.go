@@ -34,7 +34,7 @@ type TrackFragmentHeaderBox struct {
 	SampleDescrptionIndex uint32
 	DefaultSampleDuration uint32
 	DefaultSampleSize     uint32
-	DefaultSampleFlags    uint32
+	DefaultSampleFlags    SampleFlags
 }
 
 const (
@@ -134,9 +134,11 @@ func (b *TrackFragmentHeaderBox) Mp4BoxRead(r io.Reader, header *Header) (err er
 		}
 	}
 	if flags&FLAG_TFHD_DEFAULT_SAMPLE_FLAGS > 0 {
-		if err = binary.Read(r, binary.BigEndian, &b.DefaultSampleFlags); err != nil {
+		var tmp uint32
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
 			return
 		}
+		b.DefaultSampleFlags = DecodeSampleFlags(tmp)
 	}
 	return
 }
@@ -170,7 +172,7 @@ func (b *TrackFragmentHeaderBox) Mp4BoxWrite(w io.Writer) (err error) {
 		}
 	}
 	if flags&FLAG_TFHD_DEFAULT_SAMPLE_FLAGS > 0 {
-		if err = binary.Write(w, binary.BigEndian, b.DefaultSampleFlags); err != nil {
+		if err = binary.Write(w, binary.BigEndian, b.DefaultSampleFlags.Encode()); err != nil {
 			return
 		}
 	}
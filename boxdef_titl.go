@@ -0,0 +1,123 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	"golang.org/x/text/language"
+)
+
+// 3GPP TS 26.244 8.2 ‐ 8.7 Asset information
+
+// Box Types: ‘titl’, ‘dscp’, ‘cprt’, ‘perf’, ‘auth’, ‘gnre’
+// Container: User Data Box (‘udta’)
+// Mandatory: No
+// Quantity: Zero or more, at most one per language
+
+// The AssetInformationBox carries a language tagged title (‘titl’),
+// description (‘dscp’), copyright notice (‘cprt’), performer (‘perf’), author
+// (‘auth’) or genre (‘gnre’) of the presentation or track. The box type tells
+// which. The copyright box is also defined by ISO/IEC 14496‐12 8.10.2 with the
+// same syntax.
+type AssetInformationBox struct {
+	FullHeader
+	NullContainer
+
+	// declares the language code of the value, packed as in the media
+	// header box.
+	Language language.Base
+
+	Value string
+
+	// is set if the value is stored in UTF‐16 with a byte order mark rather
+	// than in UTF‐8.
+	UTF16 bool
+}
+
+var _ Box = (*AssetInformationBox)(nil)
+
+func init() {
+	BoxRegistry[TitlBoxType] = func() Box { return &AssetInformationBox{} }
+	BoxRegistry[DscpBoxType] = func() Box { return &AssetInformationBox{} }
+	BoxRegistry[CprtBoxType] = func() Box { return &AssetInformationBox{} }
+	BoxRegistry[PerfBoxType] = func() Box { return &AssetInformationBox{} }
+	BoxRegistry[AuthBoxType] = func() Box { return &AssetInformationBox{} }
+	BoxRegistry[GnreBoxType] = func() Box { return &AssetInformationBox{} }
+}
+
+func (b *AssetInformationBox) encodedValue() []byte {
+	if !b.UTF16 {
+		return append([]byte(b.Value), 0)
+	}
+	u := utf16.Encode([]rune(b.Value))
+	value := make([]byte, 2*len(u)+4)
+	binary.BigEndian.PutUint16(value, 0xFEFF)
+	for i, c := range u {
+		binary.BigEndian.PutUint16(value[2+2*i:], c)
+	}
+	return value
+}
+
+func (b *AssetInformationBox) Mp4BoxUpdate() uint32 {
+	b.Size = b.headerSize()
+	// bit(1) pad = 0;
+	// unsigned int(5)[3] language;
+	b.Size += 2
+	b.Size += uint32(len(b.encodedValue())) // string value;
+	return b.Size
+}
+
+func (b *AssetInformationBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize()+2 {
+		err = fmt.Errorf("%s box has invalid size %d: %w", b.Type, b.Size, ErrInvalidFormat)
+		return
+	}
+	var lang uint16
+	if err = binary.Read(r, binary.BigEndian, &lang); err != nil {
+		return
+	}
+	if b.Language, err = readPackedLanguage(lang); err != nil {
+		return
+	}
+	tmp := make([]byte, b.Size-b.headerSize()-2)
+	if _, err = io.ReadFull(r, tmp); err != nil {
+		return
+	}
+	b.UTF16 = len(tmp) >= 2 && tmp[0] == 0xFE && tmp[1] == 0xFF
+	if !b.UTF16 {
+		if i := bytes.IndexByte(tmp, 0); i >= 0 {
+			tmp = tmp[:i]
+		}
+		b.Value = string(tmp)
+		return
+	}
+	u := make([]uint16, 0, len(tmp)/2-1)
+	for i := 2; i+1 < len(tmp); i += 2 {
+		c := binary.BigEndian.Uint16(tmp[i:])
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	b.Value = string(utf16.Decode(u))
+	return
+}
+
+func (b *AssetInformationBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, packLanguage(b.Language)); err != nil {
+		return
+	}
+	if _, err = w.Write(b.encodedValue()); err != nil {
+		return
+	}
+	return
+}
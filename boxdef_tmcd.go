@@ -0,0 +1,134 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// QuickTime File Format Timecode Sample Description
+
+// Box Type: ‘tmcd’
+// Container: Sample Description Box (‘stsd’)
+// Mandatory: Yes
+// Quantity: One or more
+
+// Timecode tracks use the TimecodeSampleEntryBox. Each sample of the track is
+// a 32‐bit frame number, counted in frames of FrameDuration, from which the
+// SMPTE timecode of the corresponding media time is derived. The entry may
+// contain a QuickTime ‘name’ box with the name of the timecode source.
+//
+// The box type is shared with the TimecodeMediaBox inside the ‘gmhd’ box,
+// which is read by BaseMediaInformationHeaderBox.
+type TimecodeSampleEntryBox struct {
+	SampleEntry
+
+	Flags TimecodeFlags
+
+	// is the time scale of FrameDuration.
+	Timescale uint32
+
+	// is the duration of a frame in units of Timescale.
+	FrameDuration uint32
+
+	// is the number of frames per second, rounded up for fractional rates,
+	// e.g. 30 for 29.97 frames per second.
+	NumberOfFrames uint8
+}
+
+type TimecodeFlags uint32
+
+const (
+	// indicates that the timecode drops frame numbers to stay in sync with
+	// the NTSC frame rate.
+	TimecodeFlagDropFrame TimecodeFlags = 0x0001
+
+	// indicates that the timecode wraps after 24 hours.
+	TimecodeFlag24HourMax TimecodeFlags = 0x0002
+
+	// indicates that negative time values are allowed.
+	TimecodeFlagNegativeTimesOK TimecodeFlags = 0x0004
+
+	// indicates that the samples hold a counter instead of a timecode.
+	TimecodeFlagCounter TimecodeFlags = 0x0008
+)
+
+var _ Box = (*TimecodeSampleEntryBox)(nil)
+
+func init() {
+	BoxRegistry[TmcdBoxType] = func() Box { return &TimecodeSampleEntryBox{} }
+}
+
+func (b TimecodeSampleEntryBox) Mp4BoxType() BoxType {
+	return TmcdBoxType
+}
+
+func (b *TimecodeSampleEntryBox) TimecodeSampleEntrySize() (size uint32) {
+	size = b.SampleEntrySize()
+	size += 4 // unsigned int(32) reserved = 0;
+	size += 4 // unsigned int(32) flags;
+	size += 4 // unsigned int(32) timescale;
+	size += 4 // unsigned int(32) frame_duration;
+	size += 1 // unsigned int(8) number_of_frames;
+	size += 1 // unsigned int(8) reserved = 0;
+	return
+}
+
+func (b *TimecodeSampleEntryBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.TimecodeSampleEntrySize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *TimecodeSampleEntryBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.SampleEntry.Mp4BoxRead(r, header); err != nil {
+		return
+	}
+	var reserved uint32
+	if err = binary.Read(r, binary.BigEndian, &reserved); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Flags); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.Timescale); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.FrameDuration); err != nil {
+		return
+	}
+	var tmp [2]uint8
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	b.NumberOfFrames = tmp[0]
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.TimecodeSampleEntrySize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *TimecodeSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.SampleEntry.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint32(0)); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Flags); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.Timescale); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.FrameDuration); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, [2]uint8{b.NumberOfFrames, 0}); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
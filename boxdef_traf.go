@@ -60,3 +60,29 @@ func (b *TrackFragmentBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// TrackFragmentHeader returns the track fragment header box, or nil if there
+// is none.
+func (b *TrackFragmentBox) TrackFragmentHeader() *TrackFragmentHeaderBox {
+	tfhd, _ := b.Mp4BoxFindFirst(TfhdBoxType).(*TrackFragmentHeaderBox)
+	return tfhd
+}
+
+// BaseMediaDecodeTime returns the decode time of the ‘tfdt’ box. ok is false
+// if there is none.
+func (b *TrackFragmentBox) BaseMediaDecodeTime() (decodeTime uint64, ok bool) {
+	if tfdt, found := b.Mp4BoxFindFirst(TfdtBoxType).(*TrackFragmentBaseMediaDecodeTimeBox); found {
+		return tfdt.BaseMediaDecodeTime, true
+	}
+	return
+}
+
+// TrackRuns returns the track run boxes in order.
+func (b *TrackFragmentBox) TrackRuns() (truns []*TrackRunBox) {
+	for _, box := range b.Mp4BoxFindAll(TrunBoxType) {
+		if trun, ok := box.(*TrackRunBox); ok {
+			truns = append(truns, trun)
+		}
+	}
+	return
+}
@@ -65,3 +65,231 @@ func (b *TrackBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// TrackReferences returns the IDs of the tracks referenced with the given
+// reference type, e.g. ChapBoxType for the chapter tracks of a QuickTime track
+// or VdepBoxType for the base layer of a Dolby Vision enhancement layer track.
+func (b *TrackBox) TrackReferences(referenceType BoxType) []uint32 {
+	if tref, ok := b.Mp4BoxFindFirst(TrefBoxType).(*TrackReferenceBox); ok {
+		return tref.References(referenceType)
+	}
+	return nil
+}
+
+// SetTrackReferences replaces the references of the given reference type. The
+// track reference box is created if needed and removed once it is empty.
+func (b *TrackBox) SetTrackReferences(referenceType BoxType, trackIDs []uint32) {
+	tref, ok := b.Mp4BoxFindFirst(TrefBoxType).(*TrackReferenceBox)
+	if !ok {
+		if len(trackIDs) == 0 {
+			return
+		}
+		tref = &TrackReferenceBox{}
+		b.Mp4BoxAppend(tref)
+	}
+	tref.SetReferences(referenceType, trackIDs)
+	if len(tref.Children) == 0 {
+		removeChildren(&b.Container, TrefBoxType, func(Box) bool { return true })
+	}
+}
+
+// Kinds returns the roles of the track given by the KindBoxes of its user
+// data box.
+func (b *TrackBox) Kinds() (kinds []*KindBox) {
+	if udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox); ok {
+		for _, box := range udta.Mp4BoxFindAll(KindBoxType) {
+			if kind, ok := box.(*KindBox); ok {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	return
+}
+
+// HasKind reports whether the track has the role given by schemeURI and
+// value.
+func (b *TrackBox) HasKind(schemeURI, value string) bool {
+	for _, kind := range b.Kinds() {
+		if string(kind.SchemeURI) == schemeURI && string(kind.Value) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// AddKind adds the role given by schemeURI and value, unless the track
+// already has it.
+func (b *TrackBox) AddKind(schemeURI, value string) {
+	if b.HasKind(schemeURI, value) {
+		return
+	}
+	b.userData().Mp4BoxAppend(&KindBox{SchemeURI: NullTerminatedString(schemeURI), Value: NullTerminatedString(value)})
+}
+
+// RemoveKind removes the role given by schemeURI and value.
+func (b *TrackBox) RemoveKind(schemeURI, value string) {
+	if udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox); ok {
+		removeChildren(&udta.Container, KindBoxType, func(box Box) bool {
+			kind, ok := box.(*KindBox)
+			return ok && string(kind.SchemeURI) == schemeURI && string(kind.Value) == value
+		})
+	}
+}
+
+// Labels returns the LabelBoxes of the user data box of the track.
+func (b *TrackBox) Labels() (labels []*LabelBox) {
+	if udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox); ok {
+		for _, box := range udta.Mp4BoxFindAll(LablBoxType) {
+			if label, ok := box.(*LabelBox); ok {
+				labels = append(labels, label)
+			}
+		}
+	}
+	return
+}
+
+// Label returns the track label in the given language, or the first track
+// label if language is empty.
+func (b *TrackBox) Label(language string) (label string, ok bool) {
+	for _, labl := range b.Labels() {
+		if !labl.IsGroupLabel && (language == "" || string(labl.Language) == language) {
+			return string(labl.Label), true
+		}
+	}
+	return
+}
+
+// SetLabel sets the track label in the given language, replacing an existing
+// track label in that language. An empty label removes it.
+func (b *TrackBox) SetLabel(labelID uint16, language, label string) {
+	matches := func(box Box) bool {
+		labl, ok := box.(*LabelBox)
+		return ok && !labl.IsGroupLabel && string(labl.Language) == language
+	}
+	if label == "" {
+		if udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox); ok {
+			removeChildren(&udta.Container, LablBoxType, matches)
+		}
+		return
+	}
+	udta := b.userData()
+	for _, box := range udta.Mp4BoxFindAll(LablBoxType) {
+		if matches(box) {
+			labl := box.(*LabelBox)
+			labl.LabelID = labelID
+			labl.Label = NullTerminatedString(label)
+			return
+		}
+	}
+	udta.Mp4BoxAppend(&LabelBox{LabelID: labelID, Language: NullTerminatedString(language), Label: NullTerminatedString(label)})
+}
+
+// IsForced reports whether the track is a forced subtitle track, either by
+// its DASH role or by the QuickTime display flags of its text sample entry.
+func (b *TrackBox) IsForced() bool {
+	if b.HasKind(DASHRoleSchemeURI, DASHRoleForcedSubtitle) {
+		return true
+	}
+	if tx3g, ok := b.Mp4BoxRecursiveFindFirst(Tx3gBoxType).(*TextSampleEntryBox); ok {
+		return tx3g.DisplayFlags&TextDisplayFlagAllSamplesAreForced != 0
+	}
+	return false
+}
+
+// IsAudioDescription reports whether the track is marked as audio
+// description for the visually impaired by any of the common role schemes.
+func (b *TrackBox) IsAudioDescription() bool {
+	return b.HasKind(DASHRoleSchemeURI, DASHRoleDescription) ||
+		b.HasKind(TVAAudioPurposeSchemeURI, TVAAudioPurposeVisualImpaired) ||
+		b.HasKind(HTMLKindSchemeURI, HTMLKindDescriptions) ||
+		b.HasKind(HTMLKindSchemeURI, HTMLKindMainDesc)
+}
+
+// userData returns the user data box of the track, creating it if needed.
+func (b *TrackBox) userData() *UserDataBox {
+	udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox)
+	if !ok {
+		udta = &UserDataBox{}
+		b.Mp4BoxAppend(udta)
+	}
+	return udta
+}
+
+// removeChildren removes the children of the given type for which match
+// returns true.
+func removeChildren(b *Container, boxType BoxType, match func(Box) bool) {
+	children := make([]Box, 0, len(b.Children))
+	for _, child := range b.Children {
+		if child.Mp4BoxType() != boxType || !match(child) {
+			children = append(children, child)
+		}
+	}
+	b.Mp4BoxReplaceChildren(children)
+}
+
+// TrackHeader returns the track header box, or nil if there is none.
+func (b *TrackBox) TrackHeader() *TrackHeaderBox {
+	tkhd, _ := b.Mp4BoxFindFirst(TkhdBoxType).(*TrackHeaderBox)
+	return tkhd
+}
+
+// MediaHeader returns the media header box of the media box, or nil if there
+// is none.
+func (b *TrackBox) MediaHeader() *MediaHeaderBox {
+	if mdia, ok := b.Mp4BoxFindFirst(MdiaBoxType).(*MediaBox); ok {
+		mdhd, _ := mdia.Mp4BoxFindFirst(MdhdBoxType).(*MediaHeaderBox)
+		return mdhd
+	}
+	return nil
+}
+
+// Handler returns the handler box of the media box, or nil if there is none.
+func (b *TrackBox) Handler() *HandlerBox {
+	if mdia, ok := b.Mp4BoxFindFirst(MdiaBoxType).(*MediaBox); ok {
+		hdlr, _ := mdia.Mp4BoxFindFirst(HdlrBoxType).(*HandlerBox)
+		return hdlr
+	}
+	return nil
+}
+
+// SampleTable returns the sample table box of the media information box, or
+// nil if there is none.
+func (b *TrackBox) SampleTable() *SampleTableBox {
+	if mdia, ok := b.Mp4BoxFindFirst(MdiaBoxType).(*MediaBox); ok {
+		if minf, ok := mdia.Mp4BoxFindFirst(MinfBoxType).(*MediaInformationBox); ok {
+			stbl, _ := minf.Mp4BoxFindFirst(StblBoxType).(*SampleTableBox)
+			return stbl
+		}
+	}
+	return nil
+}
+
+// EditList returns the edit list box of the edit box, or nil if there is
+// none.
+func (b *TrackBox) EditList() *EditListBox {
+	if edts, ok := b.Mp4BoxFindFirst(EdtsBoxType).(*EditBox); ok {
+		elst, _ := edts.Mp4BoxFindFirst(ElstBoxType).(*EditListBox)
+		return elst
+	}
+	return nil
+}
+
+// setEditList replaces the edit box of the track by one holding elst, placed
+// before the media box.
+func (b *TrackBox) setEditList(elst *EditListBox) {
+	edts := &EditBox{}
+	edts.Mp4BoxAppend(elst)
+	removeChildren(&b.Container, EdtsBoxType, func(Box) bool { return true })
+	children := make([]Box, 0, len(b.Children)+1)
+	for _, child := range b.Children {
+		if child.Mp4BoxType() == MdiaBoxType && edts != nil {
+			children = append(children, edts)
+			edts = nil
+		}
+		children = append(children, child)
+	}
+	if edts != nil {
+		children = append(children, edts)
+	}
+	b.Mp4BoxReplaceChildren(children)
+}
@@ -0,0 +1,148 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 8.3.3 Track Reference Box
+
+// Box Type: ‘tref’
+// Container: Track Box (‘trak’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// This box provides a reference from the containing track to another track in
+// the presentation. These references are typed. A ‘hint’ reference links from
+// the containing hint track to the media data that it hints. A content
+// description reference ‘cdsc’ links a descriptive or metadata track to the
+// content which it describes. A ‘font’ reference indicates that the track uses
+// fonts carried in the referenced track, a ‘vdep’ reference links an auxiliary
+// depth or enhancement layer video track to the track it depends on, and the
+// QuickTime ‘chap’ and ‘sbtl’ references link a track to its chapter and
+// subtitle tracks.
+//
+// Exactly one TrackReferenceTypeBox of a given type shall occur in the track
+// reference box. All children are read as TrackReferenceTypeBox, whatever
+// their type.
+type TrackReferenceBox struct {
+	Header
+	Container
+}
+
+// The TrackReferenceTypeBox holds the track IDs of the referenced tracks, its
+// box type is the reference type. A track ID of 0 is allowed and means the
+// reference is unused.
+type TrackReferenceTypeBox struct {
+	Header
+	NullContainer
+
+	TrackIDs []uint32
+}
+
+var _ Box = (*TrackReferenceBox)(nil)
+var _ Box = (*TrackReferenceTypeBox)(nil)
+
+func init() {
+	BoxRegistry[TrefBoxType] = func() Box { return &TrackReferenceBox{} }
+}
+
+func (b TrackReferenceBox) Mp4BoxType() BoxType {
+	return TrefBoxType
+}
+
+// References returns the track IDs of the given reference type, or nil if
+// there is no reference of the type.
+func (b *TrackReferenceBox) References(referenceType BoxType) []uint32 {
+	if ref, ok := b.Mp4BoxFindFirst(referenceType).(*TrackReferenceTypeBox); ok {
+		return ref.TrackIDs
+	}
+	return nil
+}
+
+// SetReferences replaces the track IDs of the given reference type. The
+// reference type box is removed if trackIDs is empty.
+func (b *TrackReferenceBox) SetReferences(referenceType BoxType, trackIDs []uint32) {
+	if ref, ok := b.Mp4BoxFindFirst(referenceType).(*TrackReferenceTypeBox); ok {
+		if len(trackIDs) > 0 {
+			ref.TrackIDs = trackIDs
+			return
+		}
+		removeChildren(&b.Container, referenceType, func(Box) bool { return true })
+	} else if len(trackIDs) > 0 {
+		b.Mp4BoxAppend(&TrackReferenceTypeBox{Header: Header{Type: referenceType}, TrackIDs: trackIDs})
+	}
+}
+
+func (b *TrackReferenceBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *TrackReferenceBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	remainingSize := int64(b.Size - b.HeaderSize())
+	for remainingSize > 0 {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			return
+		}
+		child := &TrackReferenceTypeBox{}
+		if err = child.Mp4BoxRead(r, header); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *TrackReferenceBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
+
+func (b *TrackReferenceTypeBox) Mp4BoxUpdate() uint32 {
+	b.Size = b.HeaderSize()
+	b.Size += 4 * uint32(len(b.TrackIDs)) // unsigned int(32) track_IDs[];
+	return b.Size
+}
+
+func (b *TrackReferenceTypeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize() || (b.Size-b.HeaderSize())%4 != 0 {
+		err = fmt.Errorf("track reference type box %s has invalid size %d: %w", b.Type, b.Size, ErrInvalidFormat)
+		return
+	}
+	b.TrackIDs = make([]uint32, (b.Size-b.HeaderSize())/4)
+	if err = binary.Read(r, binary.BigEndian, b.TrackIDs); err != nil {
+		return
+	}
+	return
+}
+
+func (b *TrackReferenceTypeBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.TrackIDs); err != nil {
+		return
+	}
+	return
+}
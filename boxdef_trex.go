@@ -53,7 +53,7 @@ type TrackExtendsBox struct {
 	DefaultSampleDescrptionIndex uint32
 	DefaultSampleDuration        uint32
 	DefaultSampleSize            uint32
-	DefaultSampleFlags           uint32
+	DefaultSampleFlags           SampleFlags
 }
 
 var _ Box = (*TrackExtendsBox)(nil)
@@ -93,9 +93,11 @@ func (b *TrackExtendsBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
 	if err = binary.Read(r, binary.BigEndian, &b.DefaultSampleSize); err != nil {
 		return
 	}
-	if err = binary.Read(r, binary.BigEndian, &b.DefaultSampleFlags); err != nil {
+	var tmp uint32
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
 		return
 	}
+	b.DefaultSampleFlags = DecodeSampleFlags(tmp)
 	return
 }
 
@@ -115,7 +117,7 @@ func (b *TrackExtendsBox) Mp4BoxWrite(w io.Writer) (err error) {
 	if err = binary.Write(w, binary.BigEndian, b.DefaultSampleSize); err != nil {
 		return
 	}
-	if err = binary.Write(w, binary.BigEndian, b.DefaultSampleFlags); err != nil {
+	if err = binary.Write(w, binary.BigEndian, b.DefaultSampleFlags.Encode()); err != nil {
 		return
 	}
 	return
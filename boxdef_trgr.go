@@ -0,0 +1,143 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 8.3.4 Track Group Box
+
+// Box Type: ‘trgr’
+// Container: Track Box (‘trak’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// This box enables indication of groups of tracks, where each group shares a
+// particular characteristic or the tracks within a group have a particular
+// relationship. The box contains zero or more boxes, and the particular
+// characteristic or the relationship is indicated by the box type of the
+// contained boxes. A ‘msrc’ group indicates that the tracks belong to the same
+// multi‐source presentation, e.g. the audio and video of one participant of a
+// video conference.
+//
+// All children are read as TrackGroupTypeBox, whatever their type.
+type TrackGroupBox struct {
+	Header
+	Container
+}
+
+// The TrackGroupTypeBox assigns the track to the group identified by
+// TrackGroupID, its box type is the grouping type. Tracks with the same
+// grouping type and TrackGroupID belong to the same group.
+type TrackGroupTypeBox struct {
+	FullHeader
+	NullContainer
+
+	TrackGroupID uint32
+
+	// holds the data of box types which extend the TrackGroupTypeBox.
+	UnknownData []byte
+}
+
+var _ Box = (*TrackGroupBox)(nil)
+var _ Box = (*TrackGroupTypeBox)(nil)
+
+func init() {
+	BoxRegistry[TrgrBoxType] = func() Box { return &TrackGroupBox{} }
+}
+
+func (b TrackGroupBox) Mp4BoxType() BoxType {
+	return TrgrBoxType
+}
+
+// Group returns the track group ID of the given grouping type.
+func (b *TrackGroupBox) Group(groupingType BoxType) (trackGroupID uint32, ok bool) {
+	var group *TrackGroupTypeBox
+	if group, ok = b.Mp4BoxFindFirst(groupingType).(*TrackGroupTypeBox); ok {
+		trackGroupID = group.TrackGroupID
+	}
+	return
+}
+
+func (b *TrackGroupBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *TrackGroupBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	remainingSize := int64(b.Size - b.HeaderSize())
+	for remainingSize > 0 {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			return
+		}
+		child := &TrackGroupTypeBox{}
+		if err = child.Mp4BoxRead(r, header); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *TrackGroupBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
+
+func (b *TrackGroupTypeBox) Mp4BoxUpdate() uint32 {
+	b.Size = b.headerSize()
+	b.Size += 4 // unsigned int(32) track_group_id;
+	b.Size += uint32(len(b.UnknownData))
+	return b.Size
+}
+
+func (b *TrackGroupTypeBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize()+4 {
+		err = fmt.Errorf("track group type box %s has invalid size %d: %w", b.Type, b.Size, ErrInvalidFormat)
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.TrackGroupID); err != nil {
+		return
+	}
+	b.UnknownData = nil
+	if size := b.Size - b.headerSize() - 4; size > 0 {
+		b.UnknownData = make([]byte, size)
+		if _, err = io.ReadFull(r, b.UnknownData); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *TrackGroupTypeBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.TrackGroupID); err != nil {
+		return
+	}
+	if _, err = w.Write(b.UnknownData); err != nil {
+		return
+	}
+	return
+}
@@ -39,7 +39,7 @@ type TrackRunBox struct {
 	DataOffset int32
 
 	// provides a set of flags for the first sample only of this run.
-	FirstSampleFlags uint32
+	FirstSampleFlags SampleFlags
 
 	Samples []TrackRunSampleEntry
 }
@@ -47,7 +47,7 @@ type TrackRunBox struct {
 type TrackRunSampleEntry struct {
 	SampleDuration              uint32
 	SampleSize                  uint32
-	SampleFlags                 uint32
+	SampleFlags                 SampleFlags
 	SampleCompositionTimeOffset int64
 }
 
@@ -90,6 +90,7 @@ func (b *TrackRunBox) Mp4BoxUpdate() uint32 {
 	b.Type = b.Mp4BoxType()
 	b.Size = b.headerSize()
 	b.Size += 4 // unsigned int(32) sample_count;
+	b.SampleCount = uint32(len(b.Samples))
 	flags := b.Mp4BoxFlags()
 	if flags&FLAG_TRUN_DATA_OFFSET > 0 {
 		b.Size += 4 // signed int(32) data_offset;
@@ -128,9 +129,11 @@ func (b *TrackRunBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
 		}
 	}
 	if flags&FLAG_TRUN_FIRST_SAMPLE_FLAGS > 0 {
-		if err = binary.Read(r, binary.BigEndian, &b.FirstSampleFlags); err != nil {
+		var tmp uint32
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
 			return
 		}
+		b.FirstSampleFlags = DecodeSampleFlags(tmp)
 	}
 	b.Samples = make([]TrackRunSampleEntry, b.SampleCount)
 	for i := uint32(0); i < b.SampleCount; i++ {
@@ -145,9 +148,11 @@ func (b *TrackRunBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
 			}
 		}
 		if flags&FLAG_TRUN_SAMPLE_FLAGS > 0 {
-			if err = binary.Read(r, binary.BigEndian, &b.Samples[i].SampleFlags); err != nil {
+			var tmp uint32
+			if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
 				return
 			}
+			b.Samples[i].SampleFlags = DecodeSampleFlags(tmp)
 		}
 		if flags&FLAG_TRUN_SAMPLE_COMPOSITION_TIME_OFFSET > 0 {
 			if b.Version == 0 {
@@ -182,7 +187,7 @@ func (b *TrackRunBox) Mp4BoxWrite(w io.Writer) (err error) {
 		}
 	}
 	if flags&FLAG_TRUN_FIRST_SAMPLE_FLAGS > 0 {
-		if err = binary.Write(w, binary.BigEndian, b.FirstSampleFlags); err != nil {
+		if err = binary.Write(w, binary.BigEndian, b.FirstSampleFlags.Encode()); err != nil {
 			return
 		}
 	}
@@ -198,7 +203,7 @@ func (b *TrackRunBox) Mp4BoxWrite(w io.Writer) (err error) {
 			}
 		}
 		if flags&FLAG_TRUN_SAMPLE_FLAGS > 0 {
-			if err = binary.Write(w, binary.BigEndian, sample.SampleFlags); err != nil {
+			if err = binary.Write(w, binary.BigEndian, sample.SampleFlags.Encode()); err != nil {
 				return
 			}
 		}
@@ -0,0 +1,170 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 3GPP TS 26.245 5.16 Sample Description Format
+
+// Box Types: ‘tx3g’
+// Container: Sample Description Box (‘stsd’)
+// Mandatory: Yes
+// Quantity: One or more
+
+// 3GPP timed text tracks use the TextSampleEntryBox. It sets the default
+// display properties of the text, which samples may override by modifier
+// boxes following the text string. The entry contains a FontTableBox mapping
+// the font identifiers of style records to font names.
+type TextSampleEntryBox struct {
+	SampleEntry
+
+	DisplayFlags TextDisplayFlags
+
+	// 0 is left/top, 1 is centered and ‐1 is right/bottom justification.
+	HorizontalJustification int8
+	VerticalJustification   int8
+
+	BackgroundColorRGBA [4]uint8
+
+	DefaultTextBox TextBoxRecord
+	DefaultStyle   TextStyleRecord
+}
+
+type TextDisplayFlags uint32
+
+const (
+	TextDisplayFlagScrollIn             TextDisplayFlags = 0x00000020
+	TextDisplayFlagScrollOut            TextDisplayFlags = 0x00000040
+	TextDisplayFlagScrollDirection      TextDisplayFlags = 0x00000180
+	TextDisplayFlagContinuousKaraoke    TextDisplayFlags = 0x00000800
+	TextDisplayFlagWriteTextVertically  TextDisplayFlags = 0x00020000
+	TextDisplayFlagFillTextRegion       TextDisplayFlags = 0x00040000
+	TextDisplayFlagSomeSamplesAreForced TextDisplayFlags = 0x40000000 // QuickTime extension
+	TextDisplayFlagAllSamplesAreForced  TextDisplayFlags = 0x80000000 // QuickTime extension
+)
+
+// ScrollDirection returns the scroll direction: 0 is up, 1 is down, 2 is from
+// right to left and 3 is from left to right.
+func (f TextDisplayFlags) ScrollDirection() uint8 {
+	return uint8(f & TextDisplayFlagScrollDirection >> 7)
+}
+
+// The TextBoxRecord defines the text box in pixels relative to the origin of
+// the track.
+type TextBoxRecord struct {
+	Top    int16
+	Left   int16
+	Bottom int16
+	Right  int16
+}
+
+// The TextStyleRecord defines the style of the characters from StartChar up to
+// but excluding EndChar. In the sample entry both are 0.
+type TextStyleRecord struct {
+	StartChar      uint16
+	EndChar        uint16
+	FontID         uint16
+	FaceStyleFlags TextFaceStyleFlags
+	FontSize       uint8
+	TextColorRGBA  [4]uint8
+}
+
+type TextFaceStyleFlags uint8
+
+const (
+	TextFaceStyleBold      TextFaceStyleFlags = 0x01
+	TextFaceStyleItalic    TextFaceStyleFlags = 0x02
+	TextFaceStyleUnderline TextFaceStyleFlags = 0x04
+)
+
+var _ Box = (*TextSampleEntryBox)(nil)
+
+func init() {
+	BoxRegistry[Tx3gBoxType] = func() Box { return &TextSampleEntryBox{} }
+}
+
+func (b *TextSampleEntryBox) TextSampleEntrySize() (size uint32) {
+	size = b.SampleEntrySize()
+	size += 4                   // unsigned int(32) displayFlags;
+	size += 1                   // signed int(8) horizontal-justification;
+	size += 1                   // signed int(8) vertical-justification;
+	size += 4                   // unsigned int(8) background-color-rgba[4];
+	size += 8                   // BoxRecord default-text-box;
+	size += TextStyleRecordSize // StyleRecord default-style;
+	return
+}
+
+func (b *TextSampleEntryBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.TextSampleEntrySize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *TextSampleEntryBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.SampleEntry.Mp4BoxRead(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.DisplayFlags); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.HorizontalJustification); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.VerticalJustification); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.BackgroundColorRGBA); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.DefaultTextBox); err != nil {
+		return
+	}
+	if err = b.DefaultStyle.Read(r); err != nil {
+		return
+	}
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.TextSampleEntrySize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *TextSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.SampleEntry.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.DisplayFlags); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.HorizontalJustification); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.VerticalJustification); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.BackgroundColorRGBA); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.DefaultTextBox); err != nil {
+		return
+	}
+	if err = b.DefaultStyle.Write(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
+
+// TextStyleRecordSize is the size of a StyleRecord in bytes.
+const TextStyleRecordSize = 12
+
+func (s *TextStyleRecord) Read(r io.Reader) (err error) {
+	return binary.Read(r, binary.BigEndian, s)
+}
+
+func (s *TextStyleRecord) Write(w io.Writer) (err error) {
+	return binary.Write(w, binary.BigEndian, s)
+}
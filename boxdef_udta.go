@@ -0,0 +1,100 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 8.10.1 User Data Box
+
+// Box Type: ‘udta’
+// Container: Movie Box (‘moov’), Track Box (‘trak’), Movie Fragment Box
+// (‘moof’) or Track Fragment Box (‘traf’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// This box contains objects that declare user information about the
+// containing box and its data (presentation or track).
+//
+// QuickTime writers end the user data with a 32‐bit zero terminator. Bytes
+// that follow the last child box and cannot start a box are kept in
+// Terminator, so the box is written back in the same form.
+type UserDataBox struct {
+	Header
+	Container
+
+	// holds the bytes following the last child box, such as the QuickTime
+	// terminator.
+	Terminator []byte
+}
+
+var _ Box = (*UserDataBox)(nil)
+
+func init() {
+	BoxRegistry[UdtaBoxType] = func() Box { return &UserDataBox{} }
+}
+
+func (b UserDataBox) Mp4BoxType() BoxType {
+	return UdtaBoxType
+}
+
+func (b *UserDataBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	b.Size += uint32(len(b.Terminator))
+	return b.Size
+}
+
+func (b *UserDataBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.Terminator = nil
+	remainingSize := int64(b.Size) - int64(b.HeaderSize())
+	for remainingSize > 0 {
+		// fewer than 8 bytes, or a zero size, cannot start a child box and
+		// end the user data.
+		var (
+			tmp [4]byte
+			n   int
+		)
+		if remainingSize >= 4 {
+			if n, err = io.ReadFull(r, tmp[:]); err != nil {
+				return
+			}
+		}
+		if remainingSize < 8 || binary.BigEndian.Uint32(tmp[:]) == 0 {
+			b.Terminator = make([]byte, remainingSize)
+			copy(b.Terminator, tmp[:n])
+			_, err = io.ReadFull(r, b.Terminator[n:])
+			return
+		}
+		var child Box
+		if child, err = ReadBox(io.MultiReader(bytes.NewReader(tmp[:]), r)); err != nil {
+			return
+		}
+		remainingSize -= int64(child.Mp4BoxSize())
+		if remainingSize < 0 {
+			err = fmt.Errorf("child box %s exceeds parent boundary: %w", child.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *UserDataBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	if _, err = w.Write(b.Terminator); err != nil {
+		return
+	}
+	return
+}
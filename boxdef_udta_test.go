@@ -0,0 +1,50 @@
+package mp4
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUserDataBoxTerminator(t *testing.T) {
+	child := []byte{0, 0, 0, 12, 'x', 'y', 'z', 'w', 1, 2, 3, 4}
+	for _, test := range []struct {
+		name       string
+		terminator []byte
+	}{
+		{"none", nil},
+		{"quicktime", []byte{0, 0, 0, 0}},
+		{"short", []byte{0, 0}},
+		{"padding", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			size := 8 + len(child) + len(test.terminator)
+			udta := append([]byte{0, 0, 0, byte(size), 'u', 'd', 't', 'a'}, child...)
+			udta = append(udta, test.terminator...)
+			moov := append([]byte{0, 0, 0, byte(8 + size), 'm', 'o', 'o', 'v'}, udta...)
+
+			box, err := ReadBox(bytes.NewReader(moov))
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, ok := box.(*MovieBox).Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox)
+			if !ok {
+				t.Fatal("moov box has no udta box")
+			}
+			if len(b.Mp4BoxChildren()) != 1 {
+				t.Fatalf("udta box has %d children, want 1", len(b.Mp4BoxChildren()))
+			}
+			if !bytes.Equal(b.Terminator, test.terminator) {
+				t.Errorf("terminator is %x, want %x", b.Terminator, test.terminator)
+			}
+
+			var buf bytes.Buffer
+			box.Mp4BoxUpdate()
+			if err = box.Mp4BoxWrite(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), moov) {
+				t.Errorf("moov box is written as %x, want %x", buf.Bytes(), moov)
+			}
+		})
+	}
+}
@@ -42,12 +42,18 @@ func init() {
 	BoxRegistry[Avc2BoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[Avc3BoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[Avc4BoxType] = func() Box { return &VisualSampleEntryBox{} }
+	BoxRegistry[EncvBoxType] = func() Box { return &VisualSampleEntryBox{} }
+	BoxRegistry[ResvBoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[Dva1BoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[DvavBoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[Dvh1BoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[DvheBoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[Hev1BoxType] = func() Box { return &VisualSampleEntryBox{} }
 	BoxRegistry[Hvc1BoxType] = func() Box { return &VisualSampleEntryBox{} }
+	BoxRegistry[Vp09BoxType] = func() Box { return &VisualSampleEntryBox{} }
+	BoxRegistry[Av01BoxType] = func() Box { return &VisualSampleEntryBox{} }
+	BoxRegistry[Vvc1BoxType] = func() Box { return &VisualSampleEntryBox{} }
+	BoxRegistry[Vvi1BoxType] = func() Box { return &VisualSampleEntryBox{} }
 }
 
 func (b *VisualSampleEntryBox) VisualSampleEntrySize() (size uint32) {
@@ -161,9 +167,9 @@ func (b *VisualSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
 	} else if len(b.CompressorName) > 0 {
 		compressorname[0] = byte(len(b.CompressorName))
 		copy(compressorname[1:32], []byte(b.CompressorName)[:])
-		if err = binary.Write(w, binary.BigEndian, compressorname); err != nil {
-			return
-		}
+	}
+	if err = binary.Write(w, binary.BigEndian, compressorname); err != nil {
+		return
 	}
 	if err = binary.Write(w, binary.BigEndian, b.Depth); err != nil {
 		return
@@ -176,3 +182,47 @@ func (b *VisualSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
 	}
 	return
 }
+
+// MasteringDisplayColourVolume returns the mastering display colour volume of
+// the ‘mdcv’ box, or of the ‘SmDm’ box if there is no ‘mdcv’ box. It returns
+// nil if neither is present.
+func (b *VisualSampleEntryBox) MasteringDisplayColourVolume() *MasteringDisplayColourVolumeBox {
+	if mdcv, ok := b.Mp4BoxFindFirst(MdcvBoxType).(*MasteringDisplayColourVolumeBox); ok {
+		return mdcv
+	}
+	if smdm, ok := b.Mp4BoxFindFirst(SmDmBoxType).(*SMPTE2086MasteringDisplayMetadataBox); ok {
+		return smdm.MasteringDisplayColourVolume()
+	}
+	return nil
+}
+
+// ContentLightLevel returns MaxCLL and MaxFALL of the ‘clli’ box, or of the
+// ‘CoLL’ box if there is no ‘clli’ box.
+func (b *VisualSampleEntryBox) ContentLightLevel() (maxCLL, maxFALL uint16, ok bool) {
+	if clli, found := b.Mp4BoxFindFirst(ClliBoxType).(*ContentLightLevelBox); found {
+		return clli.MaxContentLightLevel, clli.MaxPicAverageLightLevel, true
+	}
+	if coll, found := b.Mp4BoxFindFirst(CoLLBoxType).(*VPContentLightLevelBox); found {
+		return coll.MaxCLL, coll.MaxFALL, true
+	}
+	return
+}
+
+// IsHDR10 reports whether the sample entry signals HDR10, i.e. BT.2020
+// primaries with the PQ transfer function and mastering display metadata. The
+// colour is taken from the ‘colr’ box, or from the ‘vpcC’ box of VP9 if there
+// is no ‘colr’ box with nclx or nclc colour information.
+func (b *VisualSampleEntryBox) IsHDR10() bool {
+	found := false
+	var primaries, transfer uint16
+	for _, box := range b.Mp4BoxFindAll(ColrBoxType) {
+		if colr, ok := box.(*ColourInformationBox); ok && (colr.ColourType == NclxFourCC || colr.ColourType == NclcFourCC) {
+			found, primaries, transfer = true, colr.ColourPrimaries, colr.TransferCharacteristics
+			break
+		}
+	}
+	if vpcC, ok := b.Mp4BoxFindFirst(VpcCBoxType).(*VPCodecConfigurationBox); ok && !found {
+		found, primaries, transfer = true, uint16(vpcC.ColourPrimaries), uint16(vpcC.TransferCharacteristics)
+	}
+	return found && primaries == 9 && transfer == 16 && b.MasteringDisplayColourVolume() != nil
+}
@@ -0,0 +1,28 @@
+package mp4
+
+import "testing"
+
+func TestVisualSampleEntryIsHDR10(t *testing.T) {
+	pq := &VPCodecConfigurationBox{ColourPrimaries: 9, TransferCharacteristics: 16, MatrixCoefficients: 9}
+	for _, test := range []struct {
+		name     string
+		children []Box
+		want     bool
+	}{
+		{"colr", []Box{&ColourInformationBox{ColourType: NclxFourCC, ColourPrimaries: 9, TransferCharacteristics: 16}, &MasteringDisplayColourVolumeBox{}}, true},
+		{"vpcC", []Box{pq, &SMPTE2086MasteringDisplayMetadataBox{}}, true},
+		{"vpcC without mastering display", []Box{pq}, false},
+		{"colr overrides vpcC", []Box{pq, &ColourInformationBox{ColourType: NclxFourCC, ColourPrimaries: 1, TransferCharacteristics: 1}, &SMPTE2086MasteringDisplayMetadataBox{}}, false},
+		{"no colour", []Box{&MasteringDisplayColourVolumeBox{}}, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			b := &VisualSampleEntryBox{}
+			for _, child := range test.children {
+				b.Mp4BoxAppend(child)
+			}
+			if got := b.IsHDR10(); got != test.want {
+				t.Errorf("IsHDR10 is %t, want %t", got, test.want)
+			}
+		})
+	}
+}
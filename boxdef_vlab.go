@@ -0,0 +1,58 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.5 WebVTT Source Label Box
+
+// Box Types: ‘vlab’
+// Container: WebVTT Sample Entry (‘wvtt’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The WebVTTSourceLabelBox contains a label identifying the source of the
+// WebVTT content. Tracks with the same label share the same cue identifiers
+// and timing, which allows them to be merged or switched between.
+type WebVTTSourceLabelBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	SourceLabel string
+}
+
+var _ Box = (*WebVTTSourceLabelBox)(nil)
+
+func init() {
+	BoxRegistry[VlabBoxType] = func() Box { return &WebVTTSourceLabelBox{} }
+}
+
+func (b WebVTTSourceLabelBox) Mp4BoxType() BoxType {
+	return VlabBoxType
+}
+
+func (b *WebVTTSourceLabelBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.SourceLabel)) // boxstring source_label;
+	return b.Size
+}
+
+func (b *WebVTTSourceLabelBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.SourceLabel, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *WebVTTSourceLabelBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.SourceLabel); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,186 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// VP Codec ISO Media File Format Binding 2.2 VP Codec Configuration Box
+
+// Box Type: ‘vpcC’
+// Container: VP Sample Entry (‘vp08’, ‘vp09’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The VPCodecConfigurationBox contains the VPCodecConfigurationRecord, which
+// describes the profile, level and picture format of the stream. Only version
+// 1 of the box is interpreted; the payload of other versions is kept as is in
+// UnknownData.
+type VPCodecConfigurationBox struct {
+	FullHeader
+	NullContainer
+
+	// specifies the VP codec profile.
+	Profile uint8
+
+	// specifies the VP codec level all samples conform to, as the level
+	// number multiplied by ten, e.g. 31 for level 3.1.
+	Level uint8
+
+	// is the bit depth of the luma and color components, e.g. 8, 10 or 12.
+	BitDepth uint8
+
+	ChromaSubsampling VPChromaSubsampling
+
+	// indicates the black level and range of the luma and chroma signals.
+	// 0 is legal range and 1 is full range.
+	VideoFullRangeFlag bool
+
+	// are the values of the ISO/IEC 23091‐4/ITU‐T H.273 colour description
+	// fields.
+	ColourPrimaries         uint8
+	TransferCharacteristics uint8
+	MatrixCoefficients      uint8
+
+	// is not used for VP8 and VP9 and should be empty.
+	CodecInitializationData []byte
+
+	// holds the payload of a box version other than 1.
+	UnknownData []byte
+}
+
+type VPChromaSubsampling uint8
+
+const (
+	VPChromaSubsampling420Vertical  VPChromaSubsampling = 0
+	VPChromaSubsampling420Colocated VPChromaSubsampling = 1
+	VPChromaSubsampling422          VPChromaSubsampling = 2
+	VPChromaSubsampling444          VPChromaSubsampling = 3
+)
+
+var _ Box = (*VPCodecConfigurationBox)(nil)
+
+func init() {
+	BoxRegistry[VpcCBoxType] = func() Box { return &VPCodecConfigurationBox{} }
+}
+
+func (b VPCodecConfigurationBox) Mp4BoxType() BoxType {
+	return VpcCBoxType
+}
+
+// CodecString returns the RFC 6381 codecs parameter of the stream, e.g.
+// ‘vp09.00.10.08’. The optional fields are only included if any of them
+// differs from its default value.
+func (b *VPCodecConfigurationBox) CodecString() string {
+	codec := fmt.Sprintf("vp09.%02d.%02d.%02d", b.Profile, b.Level, b.BitDepth)
+	if b.ChromaSubsampling != VPChromaSubsampling420Colocated ||
+		b.ColourPrimaries != 1 || b.TransferCharacteristics != 1 || b.MatrixCoefficients != 1 ||
+		b.VideoFullRangeFlag {
+		var fullRange uint8
+		if b.VideoFullRangeFlag {
+			fullRange = 1
+		}
+		codec += fmt.Sprintf(".%02d.%02d.%02d.%02d.%02d", b.ChromaSubsampling, b.ColourPrimaries,
+			b.TransferCharacteristics, b.MatrixCoefficients, fullRange)
+	}
+	return codec
+}
+
+func (b *VPCodecConfigurationBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	if b.Version != 1 {
+		b.Size += uint32(len(b.UnknownData))
+		return b.Size
+	}
+	b.Size += 1 // unsigned int(8) profile;
+	b.Size += 1 // unsigned int(8) level;
+	// unsigned int(4) bitDepth;
+	// unsigned int(3) chromaSubsampling;
+	// unsigned int(1) videoFullRangeFlag;
+	b.Size += 1
+	b.Size += 1 // unsigned int(8) colourPrimaries;
+	b.Size += 1 // unsigned int(8) transferCharacteristics;
+	b.Size += 1 // unsigned int(8) matrixCoefficients;
+	b.Size += 2 // unsigned int(16) codecInitializationDataSize;
+	b.Size += uint32(len(b.CodecInitializationData))
+	return b.Size
+}
+
+func (b *VPCodecConfigurationBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize() {
+		err = fmt.Errorf("vpcC box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	b.UnknownData = nil
+	b.CodecInitializationData = nil
+	if b.Version != 1 {
+		b.UnknownData = make([]byte, b.Size-b.headerSize())
+		if _, err = io.ReadFull(r, b.UnknownData); err != nil {
+			return
+		}
+		return
+	}
+	var tmp [6]uint8
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	b.Profile = tmp[0]
+	b.Level = tmp[1]
+	b.BitDepth = tmp[2] >> 4
+	b.ChromaSubsampling = VPChromaSubsampling(tmp[2] >> 1 & 0x7)
+	b.VideoFullRangeFlag = tmp[2]&1 != 0
+	b.ColourPrimaries = tmp[3]
+	b.TransferCharacteristics = tmp[4]
+	b.MatrixCoefficients = tmp[5]
+	var codecInitializationDataSize uint16
+	if err = binary.Read(r, binary.BigEndian, &codecInitializationDataSize); err != nil {
+		return
+	}
+	if b.headerSize()+8+uint32(codecInitializationDataSize) != b.Size {
+		err = fmt.Errorf("vpcC codec initialization data does not match box size: %w", ErrInvalidFormat)
+		return
+	}
+	if codecInitializationDataSize > 0 {
+		b.CodecInitializationData = make([]byte, codecInitializationDataSize)
+		if _, err = io.ReadFull(r, b.CodecInitializationData); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *VPCodecConfigurationBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if b.Version != 1 {
+		if _, err = w.Write(b.UnknownData); err != nil {
+			return
+		}
+		return
+	}
+	if len(b.CodecInitializationData) > 0xFFFF {
+		err = fmt.Errorf("vpcC codec initialization data too large: %w", ErrInvalidFormat)
+		return
+	}
+	var flags uint8 = b.BitDepth<<4 | uint8(b.ChromaSubsampling&0x7)<<1
+	if b.VideoFullRangeFlag {
+		flags |= 1
+	}
+	tmp := [6]uint8{b.Profile, b.Level, flags, b.ColourPrimaries, b.TransferCharacteristics, b.MatrixCoefficients}
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(len(b.CodecInitializationData))); err != nil {
+		return
+	}
+	if _, err = w.Write(b.CodecInitializationData); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 Cue Source ID Box
+
+// Box Types: ‘vsid’
+// Container: WebVTT Cue Box (‘vttc’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The CueSourceIDBox identifies the source of a cue, so that cues split into
+// several samples can be reassembled. Cues from the same source have the same
+// identifier within a track.
+type CueSourceIDBox struct {
+	Header
+	NullContainer
+
+	SourceID int32
+}
+
+var _ Box = (*CueSourceIDBox)(nil)
+
+func init() {
+	BoxRegistry[VsidBoxType] = func() Box { return &CueSourceIDBox{} }
+}
+
+func (b CueSourceIDBox) Mp4BoxType() BoxType {
+	return VsidBoxType
+}
+
+func (b *CueSourceIDBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += 4 // int(32) source_ID;
+	return b.Size
+}
+
+func (b *CueSourceIDBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.SourceID); err != nil {
+		return
+	}
+	return
+}
+
+func (b *CueSourceIDBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.SourceID); err != nil {
+		return
+	}
+	return
+}
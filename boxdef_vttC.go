@@ -0,0 +1,73 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.5 WebVTT Configuration Box
+
+// Box Types: ‘vttC’
+// Container: WebVTT Sample Entry (‘wvtt’)
+// Mandatory: Yes
+// Quantity: Exactly one
+
+// The WebVTTConfigurationBox contains the WebVTT file header, i.e. the
+// ‘WEBVTT’ line and any header lines and blocks, such as STYLE and REGION
+// blocks, preceding the first cue.
+type WebVTTConfigurationBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	Config string
+}
+
+var _ Box = (*WebVTTConfigurationBox)(nil)
+
+func init() {
+	BoxRegistry[VttCBoxType] = func() Box { return &WebVTTConfigurationBox{} }
+}
+
+func (b WebVTTConfigurationBox) Mp4BoxType() BoxType {
+	return VttCBoxType
+}
+
+func (b *WebVTTConfigurationBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.Config)) // boxstring config;
+	return b.Size
+}
+
+func (b *WebVTTConfigurationBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.Config, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *WebVTTConfigurationBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.Config); err != nil {
+		return
+	}
+	return
+}
+
+// readBoxString reads a boxstring, a string filling the remainder of a box.
+func readBoxString(r io.Reader, size, headerSize uint32) (s string, err error) {
+	if size < headerSize {
+		err = fmt.Errorf("box has invalid size %d: %w", size, ErrInvalidFormat)
+		return
+	}
+	b := make([]byte, size-headerSize)
+	if _, err = io.ReadFull(r, b); err != nil {
+		return
+	}
+	s = string(b)
+	return
+}
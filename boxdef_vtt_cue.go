@@ -0,0 +1,70 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 VTT Cue Box
+
+// Box Types: ‘vttc’
+// Container: WebVTT sample
+// Mandatory: No
+// Quantity: Zero or more
+
+// Each cue active during a sample is stored in a VTTCueBox. It contains an
+// optional CueSourceIDBox, CueIDBox, CueTimeBox and CueSettingsBox followed by
+// the mandatory CuePayloadBox.
+type VTTCueBox struct {
+	Header
+	Container
+}
+
+var _ Box = (*VTTCueBox)(nil)
+
+func init() {
+	BoxRegistry[VttcBoxType] = func() Box { return &VTTCueBox{} }
+}
+
+func (b VTTCueBox) Mp4BoxType() BoxType {
+	return VttcBoxType
+}
+
+func (b *VTTCueBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *VTTCueBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize() {
+		err = fmt.Errorf("vttc box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	// the boxes of a cue come from sample data, so their sizes are checked
+	// against the cue before they are read.
+	remainingSize := b.Size - b.HeaderSize()
+	for remainingSize > 0 {
+		var child Box
+		if child, err = readBoundedBox(r, uint64(remainingSize)); err != nil {
+			return
+		}
+		remainingSize -= child.Mp4BoxSize()
+		b.Mp4BoxAppend(child)
+	}
+	return
+}
+
+func (b *VTTCueBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 VTT Additional Text Box
+
+// Box Types: ‘vtta’
+// Container: WebVTT sample
+// Mandatory: No
+// Quantity: Zero or more
+
+// The VTTAdditionalTextBox carries text found between cues in the WebVTT
+// file, such as comments, so that it is preserved.
+type VTTAdditionalTextBox struct {
+	Header
+	NullContainer
+
+	// is a boxstring, which fills the box and is not null‐terminated.
+	CueAdditionalText string
+}
+
+var _ Box = (*VTTAdditionalTextBox)(nil)
+
+func init() {
+	BoxRegistry[VttaBoxType] = func() Box { return &VTTAdditionalTextBox{} }
+}
+
+func (b VTTAdditionalTextBox) Mp4BoxType() BoxType {
+	return VttaBoxType
+}
+
+func (b *VTTAdditionalTextBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.CueAdditionalText)) // boxstring cue_additional_text;
+	return b.Size
+}
+
+func (b *VTTAdditionalTextBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	b.CueAdditionalText, err = readBoxString(r, b.Size, b.HeaderSize())
+	return
+}
+
+func (b *VTTAdditionalTextBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = io.WriteString(w, b.CueAdditionalText); err != nil {
+		return
+	}
+	return
+}
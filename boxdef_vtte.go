@@ -0,0 +1,49 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.6 VTT Empty Cue Box
+
+// Box Types: ‘vtte’
+// Container: WebVTT sample
+// Mandatory: No
+// Quantity: Zero or one
+
+// A sample containing no active cue consists of a single VTTEmptyCueBox, as
+// samples cannot be empty.
+type VTTEmptyCueBox struct {
+	Header
+	NullContainer
+}
+
+var _ Box = (*VTTEmptyCueBox)(nil)
+
+func init() {
+	BoxRegistry[VtteBoxType] = func() Box { return &VTTEmptyCueBox{} }
+}
+
+func (b VTTEmptyCueBox) Mp4BoxType() BoxType {
+	return VtteBoxType
+}
+
+func (b *VTTEmptyCueBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.HeaderSize()
+	return b.Size
+}
+
+func (b *VTTEmptyCueBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	return
+}
+
+func (b *VTTEmptyCueBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,54 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐15 11.2.4.2 VVC configuration box
+
+// Box Type: ‘vvcC’
+// Container: VVC Sample Entry (‘vvc1’, ‘vvi1’)
+// Mandatory: Yes
+// Quantity: Exactly one
+type VVCConfigurationBox struct {
+	FullHeader
+	NullContainer
+	VVCConfig VVCDecoderConfigurationRecord
+}
+
+var _ Box = (*VVCConfigurationBox)(nil)
+
+func init() {
+	BoxRegistry[VvcCBoxType] = func() Box { return &VVCConfigurationBox{} }
+}
+
+func (b VVCConfigurationBox) Mp4BoxType() BoxType {
+	return VvcCBoxType
+}
+
+func (b *VVCConfigurationBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += b.VVCConfig.RecordSize()
+	return b.Size
+}
+
+func (b *VVCConfigurationBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = b.VVCConfig.RecordRead(r); err != nil {
+		return
+	}
+	return
+}
+
+func (b *VVCConfigurationBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = b.VVCConfig.RecordWrite(w); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,62 @@
+package mp4
+
+import (
+	"io"
+)
+
+// ISO/IEC 14496‐30 7.5 Sample entry format
+
+// Box Types: ‘wvtt’
+// Container: Sample Description Box (‘stsd’)
+// Mandatory: Yes
+// Quantity: One or more
+
+// WebVTT tracks use the WVTTSampleEntryBox. The WebVTT file header, up to but
+// excluding the first cue, is stored in the WebVTTConfigurationBox, and the
+// source of the track may be labelled by the WebVTTSourceLabelBox. Samples
+// consist of VTTCueBoxes and are decoded by DecodeWebVTTSample.
+type WVTTSampleEntryBox struct {
+	SampleEntry
+}
+
+var _ Box = (*WVTTSampleEntryBox)(nil)
+
+func init() {
+	BoxRegistry[WvttBoxType] = func() Box { return &WVTTSampleEntryBox{} }
+}
+
+// Config returns the WebVTT file header of the configuration box, or an empty
+// string if there is none.
+func (b *WVTTSampleEntryBox) Config() string {
+	if config, ok := b.Mp4BoxFindFirst(VttCBoxType).(*WebVTTConfigurationBox); ok {
+		return config.Config
+	}
+	return ""
+}
+
+func (b *WVTTSampleEntryBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.SampleEntrySize()
+	b.Size += b.Mp4BoxUpdateChildren()
+	return b.Size
+}
+
+func (b *WVTTSampleEntryBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.SampleEntry.Mp4BoxRead(r, header); err != nil {
+		return
+	}
+	if err = b.Mp4BoxReadChildren(r, b.Size-b.SampleEntrySize()); err != nil {
+		return
+	}
+	return
+}
+
+func (b *WVTTSampleEntryBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.SampleEntry.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = b.Mp4BoxWriteChildren(w); err != nil {
+		return
+	}
+	return
+}
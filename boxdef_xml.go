@@ -0,0 +1,92 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// 8.11.2 XML Box
+
+// Box Type: ‘xml ’
+// Container: Meta Box (‘meta’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// When the primary data is in XML format and it is desired that the XML be
+// stored directly in the meta‐box, the XMLBox is used. The format of the XML
+// is given by the handler of the meta box, e.g. an XMP packet.
+type XMLBox struct {
+	FullHeader
+	NullContainer
+
+	// is the UTF‐8 encoded XML, including a null terminator if the writer
+	// added one.
+	XML []byte
+}
+
+var _ Box = (*XMLBox)(nil)
+
+func init() {
+	BoxRegistry[XmlBoxType] = func() Box { return &XMLBox{} }
+}
+
+func (b XMLBox) Mp4BoxType() BoxType {
+	return XmlBoxType
+}
+
+// XMLString returns the XML as a string, without a null terminator.
+func (b *XMLBox) XMLString() string {
+	if n := len(b.XML); n > 0 && b.XML[n-1] == 0 {
+		return string(b.XML[:n-1])
+	}
+	return string(b.XML)
+}
+
+// Properties parses the XML as an XMP packet into a map of simple properties,
+// see ParseXMPProperties.
+func (b *XMLBox) Properties() (map[string]string, error) {
+	return ParseXMPProperties([]byte(b.XMLString()))
+}
+
+// SetXMP replaces the XML with an XMP packet. If the new packet is not larger
+// than the current XML, it is padded with whitespace to the current size. A
+// null terminator is kept.
+func (b *XMLBox) SetXMP(packet []byte) {
+	if n := len(b.XML); n > 0 && b.XML[n-1] == 0 {
+		b.XML = append(PadXMPPacket(packet, n-1), 0)
+		return
+	}
+	b.XML = PadXMPPacket(packet, len(b.XML))
+}
+
+func (b *XMLBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += uint32(len(b.XML)) // string xml;
+	return b.Size
+}
+
+func (b *XMLBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.headerSize() {
+		err = fmt.Errorf("xml box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	b.XML = make([]byte, b.Size-b.headerSize())
+	if _, err = io.ReadFull(r, b.XML); err != nil {
+		return
+	}
+	return
+}
+
+func (b *XMLBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = w.Write(b.XML); err != nil {
+		return
+	}
+	return
+}
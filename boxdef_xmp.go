@@ -0,0 +1,89 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// XMP Specification Part 3, 1.1.4 MPEG‐4
+
+// Box Type: ‘uuid’ with user type BE7ACFCB‐97A9‐42E8‐9C71‐999491E3AFAC
+// Container: File or User Data Box (‘udta’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The XMPBox embeds an XMP packet, as written by Adobe applications. The
+// packet is usually padded with whitespace so it can be edited in place; use
+// SetPacket to replace it while keeping the box size where possible.
+type XMPBox struct {
+	Header
+	NullContainer
+
+	// is the UTF‐8 encoded XMP packet.
+	Packet []byte
+}
+
+var _ Box = (*XMPBox)(nil)
+
+func init() {
+	UUIDBoxRegistry[XMPBoxUserType] = func() Box { return &XMPBox{} }
+}
+
+func (b XMPBox) Mp4BoxType() BoxType {
+	return UuidBoxType
+}
+
+func (b XMPBox) Mp4BoxUserType() UserType {
+	return XMPBoxUserType
+}
+
+// PacketString returns the XMP packet as a string.
+func (b *XMPBox) PacketString() string {
+	return string(b.Packet)
+}
+
+// Properties parses the XMP packet into a map of simple properties, see
+// ParseXMPProperties.
+func (b *XMPBox) Properties() (map[string]string, error) {
+	return ParseXMPProperties(b.Packet)
+}
+
+// SetPacket replaces the XMP packet. If the new packet is not larger than the
+// current one, it is padded with whitespace to the current size, so the box
+// size does not change.
+func (b *XMPBox) SetPacket(packet []byte) {
+	b.Packet = PadXMPPacket(packet, len(b.Packet))
+}
+
+func (b *XMPBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.UserType = b.Mp4BoxUserType()
+	b.Size = b.HeaderSize()
+	b.Size += uint32(len(b.Packet))
+	return b.Size
+}
+
+func (b *XMPBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if b.Size < b.HeaderSize() {
+		err = fmt.Errorf("XMP box has invalid size %d: %w", b.Size, ErrInvalidFormat)
+		return
+	}
+	b.Packet = make([]byte, b.Size-b.HeaderSize())
+	if _, err = io.ReadFull(r, b.Packet); err != nil {
+		return
+	}
+	return
+}
+
+func (b *XMPBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = w.Write(b.Packet); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,59 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// 3GPP TS 26.244 8.12 Recording year
+
+// Box Type: ‘yrrc’
+// Container: User Data Box (‘udta’)
+// Mandatory: No
+// Quantity: Zero or one
+
+// The RecordingYearBox carries the year the presentation or track was
+// recorded.
+type RecordingYearBox struct {
+	FullHeader
+	NullContainer
+
+	RecordingYear uint16
+}
+
+var _ Box = (*RecordingYearBox)(nil)
+
+func init() {
+	BoxRegistry[YrrcBoxType] = func() Box { return &RecordingYearBox{} }
+}
+
+func (b RecordingYearBox) Mp4BoxType() BoxType {
+	return YrrcBoxType
+}
+
+func (b *RecordingYearBox) Mp4BoxUpdate() uint32 {
+	b.Type = b.Mp4BoxType()
+	b.Size = b.headerSize()
+	b.Size += 2 // unsigned int(16) recording_year;
+	return b.Size
+}
+
+func (b *RecordingYearBox) Mp4BoxRead(r io.Reader, header *Header) (err error) {
+	if err = b.ReadHeader(r, header); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &b.RecordingYear); err != nil {
+		return
+	}
+	return
+}
+
+func (b *RecordingYearBox) Mp4BoxWrite(w io.Writer) (err error) {
+	if err = b.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, b.RecordingYear); err != nil {
+		return
+	}
+	return
+}
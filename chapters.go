@@ -0,0 +1,258 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf16"
+)
+
+// Chapter is a chapter marker of a presentation.
+type Chapter struct {
+	Start time.Duration
+	Title string
+}
+
+// NeroChapters returns the chapters of the Nero ‘chpl’ box of the movie's user
+// data box, or nil if there is none.
+func (b *MovieBox) NeroChapters() (chapters []Chapter) {
+	udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox)
+	if !ok {
+		return
+	}
+	chpl, ok := udta.Mp4BoxFindFirst(ChplBoxType).(*ChapterListBox)
+	if !ok {
+		return
+	}
+	for _, entry := range chpl.Chapters {
+		chapters = append(chapters, Chapter{
+			Start: time.Duration(entry.StartTime) * 100,
+			Title: entry.Title,
+		})
+	}
+	return
+}
+
+// SetNeroChapters replaces the Nero ‘chpl’ box of the movie's user data box.
+// The box is removed if chapters is empty.
+func (b *MovieBox) SetNeroChapters(chapters []Chapter) (err error) {
+	if len(chapters) > 0xFF {
+		err = fmt.Errorf("chpl box cannot hold %d chapters: %w", len(chapters), ErrInvalidFormat)
+		return
+	}
+	udta, ok := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox)
+	if !ok {
+		if len(chapters) == 0 {
+			return
+		}
+		udta = &UserDataBox{}
+		b.Mp4BoxAppend(udta)
+	}
+	removeChildren(&udta.Container, ChplBoxType, func(Box) bool { return true })
+	if len(chapters) == 0 {
+		if len(udta.Children) == 0 {
+			removeChildren(&b.Container, UdtaBoxType, func(Box) bool { return true })
+		}
+		return
+	}
+	chpl := &ChapterListBox{}
+	chpl.Version = 1
+	for _, chapter := range chapters {
+		if len(chapter.Title) > 0xFF {
+			err = fmt.Errorf("chpl chapter title %q exceeds 255 bytes: %w", chapter.Title, ErrInvalidFormat)
+			return
+		}
+		chpl.Chapters = append(chpl.Chapters, ChapterListEntry{
+			StartTime: uint64(chapter.Start / 100),
+			Title:     chapter.Title,
+		})
+	}
+	udta.Mp4BoxAppend(chpl)
+	return
+}
+
+// ChapterTrack returns the QuickTime chapter track, which is the first text
+// track referenced through a ‘chap’ track reference, or nil if there is none.
+func (b *MovieBox) ChapterTrack() *TrackBox {
+	for _, trak := range b.Tracks() {
+		for _, trackID := range trak.TrackReferences(ChapBoxType) {
+			if chap := b.Track(trackID); chap != nil {
+				if hdlr := chap.Handler(); hdlr != nil && hdlr.HandlerType == TextFourCC {
+					return chap
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// QuickTimeChapters reads the chapters from the samples of the QuickTime
+// chapter track. The samples are read from r, which must give access to the
+// file holding the movie box. It returns nil if there is no chapter track.
+func (b *MovieBox) QuickTimeChapters(r io.ReaderAt) (chapters []Chapter, err error) {
+	trak := b.ChapterTrack()
+	if trak == nil {
+		return
+	}
+	mdhd := trak.MediaHeader()
+	stbl := trak.SampleTable()
+	if mdhd == nil || mdhd.Timescale == 0 || stbl == nil {
+		err = fmt.Errorf("chapter track has no media header or sample table: %w", ErrInvalidFormat)
+		return
+	}
+	var it *SampleIterator
+	if it, err = NewSampleIterator(stbl); err != nil {
+		return
+	}
+	for sample, ok := it.Next(); ok; sample, ok = it.Next() {
+		data := make([]byte, sample.Size)
+		if _, err = r.ReadAt(data, int64(sample.Offset)); err != nil {
+			return
+		}
+		var title string
+		if title, err = decodeTextSample(data); err != nil {
+			return
+		}
+		chapters = append(chapters, Chapter{
+			Start: scaleDuration(sample.DecodeTime, mdhd.Timescale),
+			Title: title,
+		})
+	}
+	return
+}
+
+// AddQuickTimeChapterTrack adds a disabled text track holding the chapters
+// and references it through ‘chap’ track references from every video and
+// audio track. The duration of the last chapter lasts until the end of the
+// movie. The samples of the track form a single chunk at dataOffset, which is
+// returned in data and must be written there by the caller.
+func (b *MovieBox) AddQuickTimeChapterTrack(chapters []Chapter, dataOffset uint32) (trak *TrackBox, data []byte, err error) {
+	if len(chapters) == 0 {
+		err = fmt.Errorf("chapter track needs at least one chapter: %w", ErrInvalidFormat)
+		return
+	}
+	mvhd := b.MovieHeader()
+	if mvhd == nil || mvhd.Timescale == 0 {
+		err = fmt.Errorf("movie has no movie header: %w", ErrInvalidFormat)
+		return
+	}
+	const timescale = 1000
+	end := mvhd.Duration * timescale / uint64(mvhd.Timescale)
+
+	stts := &TimeToSampleBox{}
+	stsz := &SampleSizeBox{}
+	for i, chapter := range chapters {
+		start := uint64(chapter.Start / time.Millisecond)
+		next := end
+		if i+1 < len(chapters) {
+			next = uint64(chapters[i+1].Start / time.Millisecond)
+		}
+		if next < start {
+			err = fmt.Errorf("chapter %q starts after the next chapter or the end of the movie: %w", chapter.Title, ErrInvalidFormat)
+			return
+		}
+		if len(chapter.Title) > 0xFFFF {
+			err = fmt.Errorf("chapter title %q too long: %w", chapter.Title, ErrInvalidFormat)
+			return
+		}
+		delta := uint32(next - start)
+		if n := len(stts.Entries); n > 0 && stts.Entries[n-1].SampleDelta == delta {
+			stts.Entries[n-1].SampleCount++
+		} else {
+			stts.Entries = append(stts.Entries, TimeToSampleEntry{SampleCount: 1, SampleDelta: delta})
+		}
+		sample := make([]byte, 2+len(chapter.Title))
+		binary.BigEndian.PutUint16(sample, uint16(len(chapter.Title)))
+		copy(sample[2:], chapter.Title)
+		stsz.Entries = append(stsz.Entries, SampleSizeEntry{EntrySize: uint32(len(sample))})
+		data = append(data, sample...)
+	}
+
+	trackID := b.NewTrackID()
+	tkhd := &TrackHeaderBox{
+		TrackID:  trackID,
+		Duration: mvhd.Duration,
+		Matrix:   unityMatrix,
+	}
+	tkhd.Mp4BoxSetFlags(FLAG_TKHD_TRACK_IN_MOVIE)
+	mdhd := &MediaHeaderBox{
+		Timescale: timescale,
+		Duration:  end,
+	}
+	hdlr := &HandlerBox{HandlerType: TextFourCC}
+
+	tx3g := &TextSampleEntryBox{}
+	tx3g.Type = Tx3gBoxType
+	tx3g.DataReferenceIndex = 1
+	tx3g.DefaultStyle.FontID = 1
+	tx3g.DefaultStyle.FontSize = 18
+	tx3g.DefaultStyle.TextColorRGBA = [4]uint8{0xFF, 0xFF, 0xFF, 0xFF}
+	tx3g.Mp4BoxAppend(&FontTableBox{Entries: []FontRecord{{FontID: 1, FontName: "Serif"}}})
+	stsd := &SampleDescriptionBox{}
+	stsd.Mp4BoxAppend(tx3g)
+
+	stbl := &SampleTableBox{}
+	stbl.Mp4BoxAppend(stsd)
+	stbl.Mp4BoxAppend(stts)
+	stbl.Mp4BoxAppend(&SampleToChunkBox{Entries: []SampleToChunkEntry{{
+		FirstChunk:            1,
+		SamplesPerChunk:       uint32(len(chapters)),
+		SampleDescrptionIndex: 1,
+	}}})
+	stbl.Mp4BoxAppend(stsz)
+	stbl.Mp4BoxAppend(&ChunkOffsetBox{Entries: []ChunkOffsetEntry{{ChunkOffset: dataOffset}}})
+
+	minf := &MediaInformationBox{}
+	minf.Mp4BoxAppend(&NullMediaHeaderBox{})
+	minf.Mp4BoxAppend(newDataInformationBox())
+	minf.Mp4BoxAppend(stbl)
+	mdia := &MediaBox{}
+	mdia.Mp4BoxAppend(mdhd)
+	mdia.Mp4BoxAppend(hdlr)
+	mdia.Mp4BoxAppend(minf)
+
+	trak = &TrackBox{}
+	trak.Mp4BoxAppend(tkhd)
+	trak.Mp4BoxAppend(mdia)
+
+	for _, other := range b.Tracks() {
+		if hdlr := other.Handler(); hdlr != nil && (hdlr.HandlerType == VideFourCC || hdlr.HandlerType == SounFourCC) {
+			other.SetTrackReferences(ChapBoxType, append(other.TrackReferences(ChapBoxType), trackID))
+		}
+	}
+	b.Mp4BoxAppend(trak)
+	return
+}
+
+// decodeTextSample returns the text of a QuickTime or 3GPP text sample, which
+// starts with the 16‐bit length of the text. Text beginning with a byte order
+// mark is UTF‐16; otherwise it is UTF‐8.
+func decodeTextSample(data []byte) (text string, err error) {
+	if len(data) < 2 {
+		err = fmt.Errorf("text sample too short: %w", ErrInvalidFormat)
+		return
+	}
+	length := int(binary.BigEndian.Uint16(data))
+	if 2+length > len(data) {
+		err = fmt.Errorf("text sample length %d exceeds sample: %w", length, ErrInvalidFormat)
+		return
+	}
+	data = data[2 : 2+length]
+	if len(data) >= 2 && data[0] == 0xFE && data[1] == 0xFF {
+		units := make([]uint16, (len(data)-2)/2)
+		for i := range units {
+			units[i] = binary.BigEndian.Uint16(data[2+2*i:])
+		}
+		text = string(utf16.Decode(units))
+		return
+	}
+	text = string(data)
+	return
+}
+
+// scaleDuration converts a time in units of the timescale to a duration.
+func scaleDuration(t uint64, timescale uint32) time.Duration {
+	return time.Duration(t/uint64(timescale))*time.Second +
+		time.Duration(t%uint64(timescale))*time.Second/time.Duration(timescale)
+}
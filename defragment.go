@@ -0,0 +1,462 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+)
+
+// Defragment writes the fragmented movie read from init, and from the media
+// segments following it, as a progressive file to w. init holds the movie box
+// and possibly movie fragments itself, as in a single fragmented file, and
+// each segment holds movie fragments and the media data they refer to.
+//
+// The sample tables of the tracks are rebuilt from the track runs of all
+// movie fragments, the movie extends box is removed and the durations of the
+// movie, track and media headers are set from the samples. Where a track
+// fragment starts later than the end of the preceding one, the last sample of
+// the preceding one is extended to fill the gap. The media of each track
+// starts at 0 in the output; tracks whose first sample is decoded later than
+// that of the earliest track are delayed by an empty edit, so that the tracks
+// stay in sync, and the media times of existing edits are moved back by the
+// decode time of the first sample of their track. The movie box is placed
+// before the media data, which is copied from the inputs chunk by chunk, so
+// that the memory used does not depend on the amount of media data.
+//
+// Sample auxiliary information located by the ‘saiz’ and ‘saio’ boxes of the
+// track fragments, such as the initialization vectors and subsample maps of
+// encrypted samples, is copied into the media data after the samples, stored
+// contiguously for each track and located by ‘saiz’ and ‘saio’ boxes in its
+// sample table. Samples of track fragments without auxiliary information,
+// such as a clear lead, get an auxiliary information size of 0. A track
+// fragment with a ‘senc’ box but no ‘saiz’ and ‘saio’ boxes is rejected.
+func Defragment(w io.Writer, init io.ReadSeeker, segments ...io.ReadSeeker) (err error) {
+	var (
+		moov      *MovieBox
+		fragments []*MovieFragment
+	)
+	if _, err = init.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	if moov, fragments, _, err = readTopLevelBoxes(init); err != nil {
+		return
+	}
+	if moov == nil {
+		err = fmt.Errorf("init segment has no moov box: %w", ErrInvalidFormat)
+		return
+	}
+	d := &defragmenter{moov: moov, tracks: make(map[uint32]*defragmentTrack)}
+	for _, trak := range moov.Tracks() {
+		tkhd, stbl := trak.TrackHeader(), trak.SampleTable()
+		if tkhd == nil || stbl == nil {
+			err = fmt.Errorf("trak box lacks tkhd or stbl box: %w", ErrInvalidFormat)
+			return
+		}
+		if !isEmptySampleTable(stbl) {
+			err = fmt.Errorf("track %d has samples outside of movie fragments: %w", tkhd.TrackID, ErrInvalidFormat)
+			return
+		}
+		d.tracks[tkhd.TrackID] = &defragmentTrack{trak: trak}
+	}
+
+	inputs := append([]io.ReadSeeker{init}, segments...)
+	for i, r := range inputs {
+		if i > 0 {
+			if _, err = r.Seek(0, io.SeekStart); err != nil {
+				return
+			}
+			if _, fragments, _, err = readTopLevelBoxes(r); err != nil {
+				return
+			}
+		}
+		for _, fragment := range fragments {
+			if err = d.addFragment(i, fragment); err != nil {
+				return
+			}
+		}
+	}
+	for _, track := range d.tracks {
+		track.flush()
+	}
+	return d.write(w, inputs)
+}
+
+type defragmenter struct {
+	moov   *MovieBox
+	tracks map[uint32]*defragmentTrack
+
+	// the ranges of the inputs to copy into the media data box, in order,
+	// and their total size.
+	ranges    []defragmentRange
+	mediaSize uint64
+}
+
+type defragmentRange struct {
+	input  int
+	offset uint64
+	size   uint64
+}
+
+type defragmentTrack struct {
+	trak    *TrackBox
+	samples SampleTableBuilder
+
+	// the last sample is held back, as its duration may grow to reach the
+	// decode time of the next track fragment.
+	last    Sample
+	held    bool
+	endTime uint64
+	count   uint32
+
+	// the decode time of the first sample, which starts the media of the
+	// track at 0 in the output.
+	startTime uint64
+
+	// the sample auxiliary information of the samples, described by the first
+	// ‘saiz’ box of the track fragments, its size for each sample and its
+	// ranges in the inputs.
+	auxInfo   *SampleAuxiliaryInformationSizesBox
+	auxSizes  []uint8
+	auxRanges []defragmentRange
+
+	// the offset of the auxiliary information in the media data, and the
+	// ‘saio’ box locating it in the output.
+	auxOffset uint64
+	saio      *SampleAuxiliaryInformationOffsetsBox
+}
+
+// addFragment adds the samples of a movie fragment of the input.
+func (d *defragmenter) addFragment(input int, fragment *MovieFragment) (err error) {
+	var trafs [][]FragmentSample
+	if trafs, err = resolveTrackFragments(d.moov, fragment.Moof, fragment.Offset); err != nil {
+		return
+	}
+	// the end of the data of the preceding track fragment, which is the
+	// implicit base data offset of the next one.
+	dataEnd := fragment.Offset
+	for i, traf := range fragment.Moof.TrackFragments() {
+		tfhd := traf.TrackFragmentHeader()
+		track, ok := d.tracks[tfhd.TrackID]
+		if !ok {
+			err = fmt.Errorf("track fragment of unknown track %d: %w", tfhd.TrackID, ErrInvalidFormat)
+			return
+		}
+		base := trackFragmentBase(tfhd, fragment.Offset, dataEnd)
+		if err = d.addAuxiliaryInformation(input, track, traf, base, uint32(len(trafs[i]))); err != nil {
+			return
+		}
+		if decodeTime, ok := traf.BaseMediaDecodeTime(); ok {
+			if track.held && decodeTime > track.endTime {
+				track.last.Duration += uint32(decodeTime - track.endTime)
+			}
+			track.endTime = decodeTime
+		}
+		if track.count == 0 && len(trafs[i]) > 0 {
+			track.startTime = track.endTime
+		}
+		for _, sample := range trafs[i] {
+			d.addRange(input, sample.Offset, uint64(sample.Size))
+			track.flush()
+			track.last = sample.Sample
+			track.last.Offset = d.mediaSize - uint64(sample.Size)
+			track.held = true
+			track.endTime += uint64(sample.Duration)
+			track.count++
+		}
+		if n := len(trafs[i]); n > 0 {
+			dataEnd = trafs[i][n-1].Offset + uint64(trafs[i][n-1].Size)
+		} else {
+			dataEnd = base
+		}
+	}
+	return
+}
+
+// addAuxiliaryInformation adds the sample auxiliary information of the count
+// samples of the track fragment, whose base data offset is base.
+func (d *defragmenter) addAuxiliaryInformation(input int, track *defragmentTrack, traf *TrackFragmentBox, base uint64, count uint32) (err error) {
+	saizBoxes, saioBoxes := traf.Mp4BoxFindAll(SaizBoxType), traf.Mp4BoxFindAll(SaioBoxType)
+	if len(saizBoxes) == 0 && len(saioBoxes) == 0 {
+		for _, child := range traf.Mp4BoxChildren() {
+			if _, ok := child.(*SampleEncryptionBox); ok {
+				err = fmt.Errorf("track %d has a senc box without saiz and saio boxes: %w", track.trak.TrackHeader().TrackID, ErrInvalidFormat)
+				return
+			}
+		}
+		return
+	}
+	if len(saizBoxes) != 1 || len(saioBoxes) != 1 {
+		err = fmt.Errorf("track fragment must have one saiz and one saio box: %w", ErrInvalidFormat)
+		return
+	}
+	saiz, ok1 := saizBoxes[0].(*SampleAuxiliaryInformationSizesBox)
+	saio, ok2 := saioBoxes[0].(*SampleAuxiliaryInformationOffsetsBox)
+	if !ok1 || !ok2 {
+		err = fmt.Errorf("track fragment has invalid saiz or saio box: %w", ErrInvalidFormat)
+		return
+	}
+	if saiz.NumSamples() != count {
+		err = fmt.Errorf("saiz box has %d of %d samples: %w", saiz.NumSamples(), count, ErrInvalidFormat)
+		return
+	}
+	if track.auxInfo == nil {
+		track.auxInfo = saiz
+	}
+	// samples of preceding track fragments without auxiliary information
+	// have a size of 0.
+	for uint32(len(track.auxSizes)) < track.count {
+		track.auxSizes = append(track.auxSizes, 0)
+	}
+
+	// there is a single offset for the auxiliary information of all samples,
+	// or one for that of the samples of each track run.
+	runCounts := []uint32{count}
+	if len(saio.Offsets) != 1 {
+		runCounts = nil
+		for _, trun := range traf.TrackRuns() {
+			runCounts = append(runCounts, trun.SampleCount)
+		}
+		if len(runCounts) != len(saio.Offsets) {
+			err = fmt.Errorf("saio box has %d offsets for %d track runs: %w", len(saio.Offsets), len(runCounts), ErrInvalidFormat)
+			return
+		}
+		var total uint32
+		for _, runCount := range runCounts {
+			total += runCount
+		}
+		if total != count {
+			err = fmt.Errorf("track runs have %d of %d samples: %w", total, count, ErrInvalidFormat)
+			return
+		}
+	}
+	var sample uint32
+	for i, runCount := range runCounts {
+		var size uint64
+		for ; runCount > 0; runCount-- {
+			infoSize := saiz.DefaultSampleInfoSize
+			if infoSize == 0 {
+				infoSize = saiz.SampleInfoSizes[sample]
+			}
+			track.auxSizes = append(track.auxSizes, infoSize)
+			size += uint64(infoSize)
+			sample++
+		}
+		rng := defragmentRange{input: input, offset: base + saio.Offsets[i], size: size}
+		if n := len(track.auxRanges); n > 0 && track.auxRanges[n-1].input == input && track.auxRanges[n-1].offset+track.auxRanges[n-1].size == rng.offset {
+			track.auxRanges[n-1].size += size
+		} else if size > 0 {
+			track.auxRanges = append(track.auxRanges, rng)
+		}
+	}
+	return
+}
+
+// addRange appends a range of the input to the media data, extending the
+// last range if it is contiguous.
+func (d *defragmenter) addRange(input int, offset, size uint64) {
+	if n := len(d.ranges); n > 0 && d.ranges[n-1].input == input && d.ranges[n-1].offset+d.ranges[n-1].size == offset {
+		d.ranges[n-1].size += size
+	} else {
+		d.ranges = append(d.ranges, defragmentRange{input: input, offset: offset, size: size})
+	}
+	d.mediaSize += size
+}
+
+// flush adds the held back sample to the sample table.
+func (t *defragmentTrack) flush() {
+	if t.held {
+		t.samples.Add(t.last)
+		t.held = false
+	}
+}
+
+// addAuxiliaryInformationBoxes appends the auxiliary information of the track
+// to the media data and adds the ‘saiz’ and ‘saio’ boxes describing it to its
+// sample table. The offset of the ‘saio’ box is set once the layout of the
+// file is known.
+func (t *defragmentTrack) addAuxiliaryInformationBoxes(d *defragmenter) {
+	for uint32(len(t.auxSizes)) < t.count {
+		t.auxSizes = append(t.auxSizes, 0)
+	}
+	saiz := &SampleAuxiliaryInformationSizesBox{SampleInfoSizes: t.auxSizes}
+	t.saio = &SampleAuxiliaryInformationOffsetsBox{Offsets: []uint64{0}}
+	if t.auxInfo.Mp4BoxFlags()&FLAG_SAIZ_AUX_INFO_TYPE != 0 {
+		saiz.Mp4BoxSetFlags(FLAG_SAIZ_AUX_INFO_TYPE)
+		saiz.AuxInfoType = t.auxInfo.AuxInfoType
+		saiz.AuxInfoTypeParameter = t.auxInfo.AuxInfoTypeParameter
+		t.saio.Mp4BoxSetFlags(FLAG_SAIO_AUX_INFO_TYPE)
+		t.saio.AuxInfoType = t.auxInfo.AuxInfoType
+		t.saio.AuxInfoTypeParameter = t.auxInfo.AuxInfoTypeParameter
+	}
+	sameSize := len(t.auxSizes) > 0 && t.auxSizes[0] != 0
+	for _, size := range t.auxSizes {
+		sameSize = sameSize && size == t.auxSizes[0]
+	}
+	if sameSize {
+		saiz.DefaultSampleInfoSize = t.auxSizes[0]
+		saiz.SampleCount = uint32(len(t.auxSizes))
+		saiz.SampleInfoSizes = nil
+	}
+
+	t.auxOffset = d.mediaSize
+	for _, rng := range t.auxRanges {
+		d.addRange(rng.input, rng.offset, rng.size)
+	}
+	stbl := t.trak.SampleTable()
+	stbl.Mp4BoxAppend(saiz)
+	stbl.Mp4BoxAppend(t.saio)
+}
+
+// updateEditList delays the presentation of the track by delay, in the movie
+// timescale, with an empty edit, and moves the media times of its edits back
+// by its start time, as its media now starts at 0. Edits without a duration,
+// as in fragmented files, are given the remaining duration of the media. The
+// duration of the track header is set to that of the edits.
+func (t *defragmentTrack) updateEditList(delay uint64, movieTimescale uint32) {
+	tkhd, mdhd := t.trak.TrackHeader(), t.trak.MediaHeader()
+	var entries []EditListEntry
+	if elst := t.trak.EditList(); elst != nil {
+		for _, entry := range elst.Entries {
+			if entry.MediaTime >= 0 {
+				if uint64(entry.MediaTime) >= t.startTime {
+					entry.MediaTime -= int64(t.startTime)
+				} else {
+					entry.MediaTime = 0
+				}
+				if entry.SegmentDuration == 0 {
+					if skipped := uint64(entry.MediaTime) * uint64(movieTimescale) / uint64(mdhd.Timescale); skipped < tkhd.Duration {
+						entry.SegmentDuration = tkhd.Duration - skipped
+					}
+				}
+			}
+			entries = append(entries, entry)
+		}
+	} else if delay > 0 {
+		entries = []EditListEntry{{SegmentDuration: tkhd.Duration, MediaRateInteger: 1}}
+	}
+	if delay > 0 {
+		entries = append([]EditListEntry{{SegmentDuration: delay, MediaTime: -1, MediaRateInteger: 1}}, entries...)
+	}
+	if entries == nil {
+		return
+	}
+	elst := &EditListBox{Entries: entries}
+	if elst.needsVersion1() {
+		elst.Version = 1
+	}
+	t.trak.setEditList(elst)
+	tkhd.Duration = 0
+	for _, entry := range entries {
+		tkhd.Duration += entry.SegmentDuration
+	}
+}
+
+// write rebuilds the movie box and writes the progressive file.
+func (d *defragmenter) write(w io.Writer, inputs []io.ReadSeeker) (err error) {
+	removeChildren(&d.moov.Container, MvexBoxType, func(Box) bool { return true })
+	mvhd := d.moov.MovieHeader()
+	if mvhd == nil || mvhd.Timescale == 0 {
+		err = fmt.Errorf("movie has no movie header: %w", ErrInvalidFormat)
+		return
+	}
+	// the tracks start after the earliest of them, in the movie timescale.
+	var (
+		starts   = make(map[uint32]uint64)
+		minStart uint64
+		started  bool
+	)
+	for _, trak := range d.moov.Tracks() {
+		track := d.tracks[trak.TrackHeader().TrackID]
+		track.samples.Build(trak.SampleTable())
+		mdhd := trak.MediaHeader()
+		if mdhd == nil || mdhd.Timescale == 0 {
+			err = fmt.Errorf("trak box has no media header: %w", ErrInvalidFormat)
+			return
+		}
+		mdhd.Duration = track.samples.Duration()
+		tkhd := trak.TrackHeader()
+		tkhd.Duration = mdhd.Duration * uint64(mvhd.Timescale) / uint64(mdhd.Timescale)
+		if mdhd.Duration > 0xFFFFFFFF {
+			mdhd.Version = 1
+		}
+		if track.auxInfo != nil {
+			track.addAuxiliaryInformationBoxes(d)
+		}
+		if track.count > 0 {
+			start := track.startTime * uint64(mvhd.Timescale) / uint64(mdhd.Timescale)
+			starts[tkhd.TrackID] = start
+			if !started || start < minStart {
+				minStart, started = start, true
+			}
+		}
+	}
+	mvhd.Duration = 0
+	for _, trak := range d.moov.Tracks() {
+		tkhd := trak.TrackHeader()
+		track := d.tracks[tkhd.TrackID]
+		if start, ok := starts[tkhd.TrackID]; ok {
+			track.updateEditList(start-minStart, mvhd.Timescale)
+		}
+		if tkhd.Duration > 0xFFFFFFFF {
+			tkhd.Version = 1
+		}
+		if tkhd.Duration > mvhd.Duration {
+			mvhd.Duration = tkhd.Duration
+		}
+	}
+	if mvhd.Duration > 0xFFFFFFFF {
+		mvhd.Version = 1
+	}
+
+	ftyp := newProgressiveFileType()
+	ftypSize := uint64(ftyp.Mp4BoxUpdate())
+	moovSize := uint64(d.moov.Mp4BoxUpdate())
+	mdatHeaderSize := mediaDataHeaderSize(d.mediaSize)
+	if err = d.moov.RelocateChunkOffsets(func(offset uint64) uint64 {
+		return ftypSize + moovSize + mdatHeaderSize + offset
+	}); err != nil {
+		return
+	}
+	// the ‘saio’ offsets follow the movie box, whose size they change if they
+	// need 64 bits.
+	for {
+		if err = fitMovieBox(d.moov, ftypSize, moovSize); err != nil {
+			return
+		}
+		moovSize = uint64(d.moov.Mp4BoxUpdate())
+		grown := false
+		for _, track := range d.tracks {
+			if track.saio == nil {
+				continue
+			}
+			offset := ftypSize + moovSize + mdatHeaderSize + track.auxOffset
+			if offset > 0xFFFFFFFF && track.saio.Version == 0 {
+				track.saio.Version = 1
+				grown = true
+			}
+			track.saio.Offsets = []uint64{offset}
+		}
+		if !grown {
+			break
+		}
+	}
+
+	if err = ftyp.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = d.moov.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = writeMediaDataHeader(w, d.mediaSize); err != nil {
+		return
+	}
+	for _, rng := range d.ranges {
+		r := inputs[rng.input]
+		if _, err = r.Seek(int64(rng.offset), io.SeekStart); err != nil {
+			return
+		}
+		if _, err = io.CopyN(w, r, int64(rng.size)); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,228 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ISO/IEC 14496‐1 7.2.2 Common data structures
+
+// Object descriptors are carried as a tag, an expandable size field and a
+// payload of sizeOfInstance bytes. The size field is coded as a sequence of
+// bytes, of which the lower 7 bits carry the size and the highest bit signals
+// that another size byte follows. Some writers always use the 4‐byte form of
+// the size field; the length actually used is remembered so that descriptors
+// can be written back unchanged.
+type DescriptorTag uint8
+
+const (
+	ObjectDescrTag        DescriptorTag = 0x01
+	InitialObjectDescrTag DescriptorTag = 0x02
+	ESDescrTag            DescriptorTag = 0x03
+	DecoderConfigDescrTag DescriptorTag = 0x04
+	DecSpecificInfoTag    DescriptorTag = 0x05
+	SLConfigDescrTag      DescriptorTag = 0x06
+)
+
+var DescriptorRegistry = make(map[DescriptorTag]func() Descriptor)
+
+type Descriptor interface {
+	DescriptorTag() DescriptorTag
+	DescriptorSize() uint32
+	DescriptorUpdate() uint32
+	DescriptorRead(r io.Reader, header *DescriptorHeader) (err error)
+	DescriptorWrite(w io.Writer) (err error)
+}
+
+func NewDescriptor(tag DescriptorTag) (d Descriptor) {
+	if fn := DescriptorRegistry[tag]; fn != nil {
+		d = fn()
+	} else {
+		d = &UnknownDescriptor{}
+	}
+	return
+}
+
+type DescriptorHeader struct {
+	Tag DescriptorTag
+
+	// is the size of the descriptor payload, excluding the tag and the size
+	// field itself.
+	SizeOfInstance uint32
+
+	// is the number of bytes used to code the size field. When writing, the
+	// shortest form able to represent SizeOfInstance is used if this is
+	// smaller.
+	SizeFieldLength uint8
+}
+
+func (h DescriptorHeader) DescriptorTag() DescriptorTag {
+	return h.Tag
+}
+
+// DescriptorSize returns the total size of the descriptor, including the tag
+// and size field.
+func (h DescriptorHeader) DescriptorSize() uint32 {
+	return 1 + uint32(h.SizeFieldLength) + h.SizeOfInstance
+}
+
+// updateHeader sets the payload size and picks the size field length.
+func (h *DescriptorHeader) updateHeader(tag DescriptorTag, sizeOfInstance uint32) uint32 {
+	h.Tag = tag
+	h.SizeOfInstance = sizeOfInstance
+	var length uint8 = 1
+	for sizeOfInstance >= 1<<(7*length) && length < 4 {
+		length++
+	}
+	if h.SizeFieldLength < length {
+		h.SizeFieldLength = length
+	}
+	return h.DescriptorSize()
+}
+
+func (h *DescriptorHeader) ReadHeader(r io.Reader, header *DescriptorHeader) (err error) {
+	if header != nil {
+		*h = *header
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &h.Tag); err != nil {
+		return
+	}
+	h.SizeOfInstance = 0
+	h.SizeFieldLength = 0
+	for {
+		var b uint8
+		if err = binary.Read(r, binary.BigEndian, &b); err != nil {
+			return
+		}
+		h.SizeOfInstance = h.SizeOfInstance<<7 | uint32(b&0x7F)
+		h.SizeFieldLength++
+		if b&0x80 == 0 {
+			break
+		}
+		if h.SizeFieldLength == 4 {
+			err = fmt.Errorf("descriptor size field exceeds 4 bytes: %w", ErrInvalidFormat)
+			return
+		}
+	}
+	return
+}
+
+func (h *DescriptorHeader) WriteHeader(w io.Writer) (err error) {
+	if err = binary.Write(w, binary.BigEndian, h.Tag); err != nil {
+		return
+	}
+	tmp := make([]byte, h.SizeFieldLength)
+	for i := range tmp {
+		tmp[i] = uint8(h.SizeOfInstance>>(7*(len(tmp)-1-i))) & 0x7F
+		if i < len(tmp)-1 {
+			tmp[i] |= 0x80
+		}
+	}
+	if _, err = w.Write(tmp); err != nil {
+		return
+	}
+	return
+}
+
+// readPayload reads the descriptor payload so that nested structures can be
+// parsed without running past the end of the descriptor.
+func (h *DescriptorHeader) readPayload(r io.Reader) (payload *bytes.Reader, err error) {
+	buf := make([]byte, h.SizeOfInstance)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return
+	}
+	payload = bytes.NewReader(buf)
+	return
+}
+
+func ReadDescriptorHeader(r io.Reader) (header *DescriptorHeader, err error) {
+	header = &DescriptorHeader{}
+	if err = header.ReadHeader(r, nil); err != nil {
+		return
+	}
+	return
+}
+
+func ReadDescriptor(r io.Reader) (d Descriptor, err error) {
+	var header *DescriptorHeader
+	if header, err = ReadDescriptorHeader(r); err != nil {
+		return
+	}
+	d = NewDescriptor(header.Tag)
+	if err = d.DescriptorRead(r, header); err != nil {
+		return
+	}
+	return
+}
+
+// readDescriptors reads descriptors until r is exhausted. The payload of each
+// descriptor must fit into the bytes left in r.
+func readDescriptors(r *bytes.Reader) (descriptors []Descriptor, err error) {
+	for r.Len() > 0 {
+		var header *DescriptorHeader
+		if header, err = ReadDescriptorHeader(r); err != nil {
+			return
+		}
+		if int64(header.SizeOfInstance) > int64(r.Len()) {
+			err = fmt.Errorf("descriptor 0x%02x of %d bytes exceeds its parent: %w", header.Tag, header.SizeOfInstance, ErrInvalidFormat)
+			return
+		}
+		d := NewDescriptor(header.Tag)
+		if err = d.DescriptorRead(r, header); err != nil {
+			return
+		}
+		descriptors = append(descriptors, d)
+	}
+	return
+}
+
+func updateDescriptors(descriptors []Descriptor) (size uint32) {
+	for _, d := range descriptors {
+		size += d.DescriptorUpdate()
+	}
+	return
+}
+
+func writeDescriptors(w io.Writer, descriptors []Descriptor) (err error) {
+	for _, d := range descriptors {
+		if err = d.DescriptorWrite(w); err != nil {
+			return
+		}
+	}
+	return
+}
+
+type UnknownDescriptor struct {
+	DescriptorHeader
+	Data []byte
+}
+
+var _ Descriptor = (*UnknownDescriptor)(nil)
+
+func (d *UnknownDescriptor) DescriptorUpdate() uint32 {
+	return d.updateHeader(d.Tag, uint32(len(d.Data)))
+}
+
+func (d *UnknownDescriptor) DescriptorRead(r io.Reader, header *DescriptorHeader) (err error) {
+	if err = d.ReadHeader(r, header); err != nil {
+		return
+	}
+	d.Data = make([]byte, d.SizeOfInstance)
+	if _, err = io.ReadFull(r, d.Data); err != nil {
+		return
+	}
+	return
+}
+
+func (d *UnknownDescriptor) DescriptorWrite(w io.Writer) (err error) {
+	if err = d.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = w.Write(d.Data); err != nil {
+		return
+	}
+	return
+}
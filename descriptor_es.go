@@ -0,0 +1,607 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ISO/IEC 14496‐1 7.2.6.5 ES_Descriptor
+
+// The ES_Descriptor conveys all information related to a particular elementary
+// stream and has three major parts.
+//
+// The first part consists of the ES_ID which is a unique reference to the
+// elementary stream within its name scope, a mechanism to describe
+// dependencies of elementary streams within the scope of the parent object
+// descriptor and an optional URL string.
+//
+// The second part consists of the component descriptors which convey the
+// parameters and requirements of the elementary stream.
+//
+// The third part is a set of optional extension descriptors that support the
+// inclusion of future extensions as well as the transport of private data in
+// a backward compatible way.
+type ESDescriptor struct {
+	DescriptorHeader
+
+	// This syntax element provides a unique label for each elementary stream
+	// within its name scope. In MP4 files it is set to 0.
+	ESID uint16
+
+	// If set to one indicates that a dependsOn_ES_ID will follow.
+	StreamDependenceFlag bool
+
+	// if set to 1 indicates that a URLstring will follow.
+	URLFlag bool
+
+	// indicates that an OCR_ES_ID syntax element will follow.
+	OCRStreamFlag bool
+
+	// indicates a relative measure for the priority of this elementary stream.
+	StreamPriority uint8
+
+	// is the ES_ID of another elementary stream on which this elementary
+	// stream depends.
+	DependsOnESID uint16
+
+	// contains a UTF‐8 encoded URL that shall point to the location of an SL‐
+	// packetized stream by name.
+	URLString string
+
+	// indicates the ES_ID of the elementary stream within the name scope from
+	// which the time base for this elementary stream is derived.
+	OCRESID uint16
+
+	DecoderConfig *DecoderConfigDescriptor
+	SLConfig      *SLConfigDescriptor
+
+	// holds the optional IPI, language, QoS, registration and extension
+	// descriptors following the SLConfigDescriptor.
+	Descriptors []Descriptor
+}
+
+var _ Descriptor = (*ESDescriptor)(nil)
+
+func init() {
+	DescriptorRegistry[ESDescrTag] = func() Descriptor { return &ESDescriptor{} }
+}
+
+func (d *ESDescriptor) DescriptorUpdate() uint32 {
+	var size uint32
+	size += 2 // bit(16) ES_ID;
+	// bit(1) streamDependenceFlag;
+	// bit(1) URL_Flag;
+	// bit(1) OCRstreamFlag;
+	// bit(5) streamPriority;
+	size += 1
+	if d.StreamDependenceFlag {
+		size += 2 // bit(16) dependsOn_ES_ID;
+	}
+	if d.URLFlag {
+		size += 1                        // bit(8) URLlength;
+		size += uint32(len(d.URLString)) // bit(8) URLstring[URLlength];
+	}
+	if d.OCRStreamFlag {
+		size += 2 // bit(16) OCR_ES_Id;
+	}
+	if d.DecoderConfig != nil {
+		size += d.DecoderConfig.DescriptorUpdate() // DecoderConfigDescriptor decConfigDescr;
+	}
+	if d.SLConfig != nil {
+		size += d.SLConfig.DescriptorUpdate() // SLConfigDescriptor slConfigDescr;
+	}
+	size += updateDescriptors(d.Descriptors)
+	return d.updateHeader(ESDescrTag, size)
+}
+
+func (d *ESDescriptor) DescriptorRead(r io.Reader, header *DescriptorHeader) (err error) {
+	if err = d.ReadHeader(r, header); err != nil {
+		return
+	}
+	payload, err := d.readPayload(r)
+	if err != nil {
+		return
+	}
+	if err = binary.Read(payload, binary.BigEndian, &d.ESID); err != nil {
+		return
+	}
+	var tmp uint8
+	if err = binary.Read(payload, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	d.StreamDependenceFlag = tmp&0x80 > 0
+	d.URLFlag = tmp&0x40 > 0
+	d.OCRStreamFlag = tmp&0x20 > 0
+	d.StreamPriority = tmp & 0x1F
+	if d.StreamDependenceFlag {
+		if err = binary.Read(payload, binary.BigEndian, &d.DependsOnESID); err != nil {
+			return
+		}
+	}
+	if d.URLFlag {
+		var length uint8
+		if err = binary.Read(payload, binary.BigEndian, &length); err != nil {
+			return
+		}
+		url := make([]byte, length)
+		if _, err = io.ReadFull(payload, url); err != nil {
+			return
+		}
+		d.URLString = string(url)
+	}
+	if d.OCRStreamFlag {
+		if err = binary.Read(payload, binary.BigEndian, &d.OCRESID); err != nil {
+			return
+		}
+	}
+	var descriptors []Descriptor
+	if descriptors, err = readDescriptors(payload); err != nil {
+		return
+	}
+	d.DecoderConfig = nil
+	d.SLConfig = nil
+	d.Descriptors = nil
+	for _, descriptor := range descriptors {
+		switch descriptor := descriptor.(type) {
+		case *DecoderConfigDescriptor:
+			if d.DecoderConfig == nil {
+				d.DecoderConfig = descriptor
+				continue
+			}
+		case *SLConfigDescriptor:
+			if d.SLConfig == nil {
+				d.SLConfig = descriptor
+				continue
+			}
+		}
+		d.Descriptors = append(d.Descriptors, descriptor)
+	}
+	return
+}
+
+func (d *ESDescriptor) DescriptorWrite(w io.Writer) (err error) {
+	if err = d.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, d.ESID); err != nil {
+		return
+	}
+	tmp := d.StreamPriority & 0x1F
+	if d.StreamDependenceFlag {
+		tmp |= 0x80
+	}
+	if d.URLFlag {
+		tmp |= 0x40
+	}
+	if d.OCRStreamFlag {
+		tmp |= 0x20
+	}
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	if d.StreamDependenceFlag {
+		if err = binary.Write(w, binary.BigEndian, d.DependsOnESID); err != nil {
+			return
+		}
+	}
+	if d.URLFlag {
+		if len(d.URLString) > 255 {
+			err = fmt.Errorf("es descriptor got URL string length exceeds 255: %w", ErrInvalidFormat)
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, uint8(len(d.URLString))); err != nil {
+			return
+		}
+		if _, err = w.Write([]byte(d.URLString)); err != nil {
+			return
+		}
+	}
+	if d.OCRStreamFlag {
+		if err = binary.Write(w, binary.BigEndian, d.OCRESID); err != nil {
+			return
+		}
+	}
+	if d.DecoderConfig != nil {
+		if err = d.DecoderConfig.DescriptorWrite(w); err != nil {
+			return
+		}
+	}
+	if d.SLConfig != nil {
+		if err = d.SLConfig.DescriptorWrite(w); err != nil {
+			return
+		}
+	}
+	if err = writeDescriptors(w, d.Descriptors); err != nil {
+		return
+	}
+	return
+}
+
+// ISO/IEC 14496‐1 7.2.6.6 DecoderConfigDescriptor
+
+// The DecoderConfigDescriptor provides information about the decoder type and
+// the required decoder resources needed for the associated elementary stream.
+// This is needed at the receiving terminal to determine whether it is able to
+// decode the elementary stream. A stream type identifies the category of the
+// stream while the optional decoder specific information descriptor contains
+// stream specific information for the set up of the decoder in a stream
+// specific format that is opaque to this layer.
+type DecoderConfigDescriptor struct {
+	DescriptorHeader
+
+	// an indication of the object or scene description type that needs to be
+	// supported by the decoder for this elementary stream.
+	ObjectTypeIndication uint8
+
+	// conveys the type of this elementary stream.
+	StreamType uint8
+
+	// indicates that this stream is used for upstream information.
+	UpStream bool
+
+	// is the size of the decoding buffer for this elementary stream in byte.
+	BufferSizeDB uint32
+
+	// is the maximum bitrate in bits per second of this elementary stream in
+	// any time window of one second duration.
+	MaxBitrate uint32
+
+	// is the average bitrate in bits per second of this elementary stream.
+	// For streams with variable bitrate this value shall be set to zero.
+	AvgBitrate uint32
+
+	DecSpecificInfo *DecoderSpecificInfo
+
+	// holds the optional profileLevelIndicationIndexDescriptors.
+	Descriptors []Descriptor
+}
+
+const (
+	ObjectTypeMPEG4Audio    uint8 = 0x40
+	ObjectTypeMPEG2AACMain  uint8 = 0x66
+	ObjectTypeMPEG2AACLC    uint8 = 0x67
+	ObjectTypeMPEG2AACSSR   uint8 = 0x68
+	ObjectTypeMPEG2Audio    uint8 = 0x69
+	ObjectTypeMPEG1Audio    uint8 = 0x6B
+	ObjectTypeAC3           uint8 = 0xA5
+	ObjectTypeEAC3          uint8 = 0xA6
+	ObjectTypeDTS           uint8 = 0xA9
+	ObjectTypeOpus          uint8 = 0xAD
+	ObjectTypeNoneSpecified uint8 = 0xFF
+)
+
+const (
+	StreamTypeObjectDescriptor uint8 = 0x01
+	StreamTypeVisual           uint8 = 0x04
+	StreamTypeAudio            uint8 = 0x05
+)
+
+var _ Descriptor = (*DecoderConfigDescriptor)(nil)
+
+func init() {
+	DescriptorRegistry[DecoderConfigDescrTag] = func() Descriptor { return &DecoderConfigDescriptor{} }
+}
+
+func (d *DecoderConfigDescriptor) DescriptorUpdate() uint32 {
+	var size uint32
+	size += 1 // bit(8) objectTypeIndication;
+	// bit(6) streamType;
+	// bit(1) upStream;
+	// const bit(1) reserved=1;
+	size += 1
+	size += 3 // bit(24) bufferSizeDB;
+	size += 4 // bit(32) maxBitrate;
+	size += 4 // bit(32) avgBitrate;
+	if d.DecSpecificInfo != nil {
+		size += d.DecSpecificInfo.DescriptorUpdate() // DecoderSpecificInfo decSpecificInfo[0 .. 1];
+	}
+	size += updateDescriptors(d.Descriptors)
+	return d.updateHeader(DecoderConfigDescrTag, size)
+}
+
+func (d *DecoderConfigDescriptor) DescriptorRead(r io.Reader, header *DescriptorHeader) (err error) {
+	if err = d.ReadHeader(r, header); err != nil {
+		return
+	}
+	payload, err := d.readPayload(r)
+	if err != nil {
+		return
+	}
+	var tmp [13]uint8
+	if err = binary.Read(payload, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	d.ObjectTypeIndication = tmp[0]
+	d.StreamType = tmp[1] >> 2
+	d.UpStream = tmp[1]&0b10 > 0
+	d.BufferSizeDB = uint32(tmp[2])<<16 | uint32(tmp[3])<<8 | uint32(tmp[4])
+	d.MaxBitrate = binary.BigEndian.Uint32(tmp[5:9])
+	d.AvgBitrate = binary.BigEndian.Uint32(tmp[9:13])
+	var descriptors []Descriptor
+	if descriptors, err = readDescriptors(payload); err != nil {
+		return
+	}
+	d.DecSpecificInfo = nil
+	d.Descriptors = nil
+	for _, descriptor := range descriptors {
+		if info, ok := descriptor.(*DecoderSpecificInfo); ok && d.DecSpecificInfo == nil {
+			d.DecSpecificInfo = info
+			continue
+		}
+		d.Descriptors = append(d.Descriptors, descriptor)
+	}
+	return
+}
+
+func (d *DecoderConfigDescriptor) DescriptorWrite(w io.Writer) (err error) {
+	if err = d.WriteHeader(w); err != nil {
+		return
+	}
+	var tmp [13]uint8
+	tmp[0] = d.ObjectTypeIndication
+	tmp[1] = d.StreamType<<2 | 0b1
+	if d.UpStream {
+		tmp[1] |= 0b10
+	}
+	tmp[2] = uint8(d.BufferSizeDB >> 16)
+	tmp[3] = uint8(d.BufferSizeDB >> 8)
+	tmp[4] = uint8(d.BufferSizeDB)
+	binary.BigEndian.PutUint32(tmp[5:9], d.MaxBitrate)
+	binary.BigEndian.PutUint32(tmp[9:13], d.AvgBitrate)
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	if d.DecSpecificInfo != nil {
+		if err = d.DecSpecificInfo.DescriptorWrite(w); err != nil {
+			return
+		}
+	}
+	if err = writeDescriptors(w, d.Descriptors); err != nil {
+		return
+	}
+	return
+}
+
+// AudioSpecificConfig decodes the decoder specific information of an MPEG‐4
+// audio stream.
+func (d *DecoderConfigDescriptor) AudioSpecificConfig() (config *AudioSpecificConfig, err error) {
+	if d.ObjectTypeIndication != ObjectTypeMPEG4Audio {
+		err = fmt.Errorf("object type indication 0x%02x is not MPEG-4 audio: %w", d.ObjectTypeIndication, ErrInvalidFormat)
+		return
+	}
+	if d.DecSpecificInfo == nil {
+		err = fmt.Errorf("MPEG-4 audio decoder config missing decoder specific info: %w", ErrInvalidFormat)
+		return
+	}
+	return ParseAudioSpecificConfig(d.DecSpecificInfo.Info)
+}
+
+// ISO/IEC 14496‐1 7.2.6.7 DecoderSpecificInfo
+
+// Decoder specific information constitutes an opaque container with
+// information for a specific media decoder. The existence and semantics of
+// decoder specific information depends on the values of
+// DecoderConfigDescriptor.streamType and
+// DecoderConfigDescriptor.objectTypeIndication.
+type DecoderSpecificInfo struct {
+	DescriptorHeader
+	Info []byte
+}
+
+var _ Descriptor = (*DecoderSpecificInfo)(nil)
+
+func init() {
+	DescriptorRegistry[DecSpecificInfoTag] = func() Descriptor { return &DecoderSpecificInfo{} }
+}
+
+func (d *DecoderSpecificInfo) DescriptorUpdate() uint32 {
+	return d.updateHeader(DecSpecificInfoTag, uint32(len(d.Info)))
+}
+
+func (d *DecoderSpecificInfo) DescriptorRead(r io.Reader, header *DescriptorHeader) (err error) {
+	if err = d.ReadHeader(r, header); err != nil {
+		return
+	}
+	d.Info = make([]byte, d.SizeOfInstance)
+	if _, err = io.ReadFull(r, d.Info); err != nil {
+		return
+	}
+	return
+}
+
+func (d *DecoderSpecificInfo) DescriptorWrite(w io.Writer) (err error) {
+	if err = d.WriteHeader(w); err != nil {
+		return
+	}
+	if _, err = w.Write(d.Info); err != nil {
+		return
+	}
+	return
+}
+
+// ISO/IEC 14496‐1 7.3.2.3 SL Packet Header Configuration
+
+// This SL packet header configuration descriptor shall be used to configure
+// the SL packet header of the elementary stream it is associated with. In MP4
+// files the predefined value 2 is used, so that no further fields are present.
+type SLConfigDescriptor struct {
+	DescriptorHeader
+
+	// allows to default the values from a set of predefined parameter sets.
+	// 0x00 means custom, 0x01 null SL packet header and 0x02 reserved for use
+	// in MP4 files.
+	Predefined uint8
+
+	// The following fields are only present if Predefined is 0.
+	UseAccessUnitStartFlag       bool
+	UseAccessUnitEndFlag         bool
+	UseRandomAccessPointFlag     bool
+	HasRandomAccessUnitsOnlyFlag bool
+	UsePaddingFlag               bool
+	UseTimeStampsFlag            bool
+	UseIdleFlag                  bool
+	DurationFlag                 bool
+	TimeStampResolution          uint32
+	OCRResolution                uint32
+	TimeStampLength              uint8
+	OCRLength                    uint8
+	AULength                     uint8
+	InstantBitrateLength         uint8
+	DegradationPriorityLength    uint8
+	AUSeqNumLength               uint8
+	PacketSeqNumLength           uint8
+
+	// The following fields are only present if DurationFlag is set.
+	TimeScale               uint32
+	AccessUnitDuration      uint16
+	CompositionUnitDuration uint16
+
+	// holds the bit‐aligned startDecodingTimeStamp and
+	// startCompositionTimeStamp fields present when UseTimeStampsFlag is not
+	// set, and any other trailing data.
+	Remaining []byte
+}
+
+const (
+	SLConfigPredefinedCustom uint8 = 0x00
+	SLConfigPredefinedNull   uint8 = 0x01
+	SLConfigPredefinedMP4    uint8 = 0x02
+)
+
+var _ Descriptor = (*SLConfigDescriptor)(nil)
+
+func init() {
+	DescriptorRegistry[SLConfigDescrTag] = func() Descriptor { return &SLConfigDescriptor{} }
+}
+
+func (d *SLConfigDescriptor) DescriptorUpdate() uint32 {
+	var size uint32
+	size += 1 // bit(8) predefined;
+	if d.Predefined == SLConfigPredefinedCustom {
+		size += 1 // bit(1) useAccessUnitStartFlag; ... bit(1) durationFlag;
+		size += 4 // bit(32) timeStampResolution;
+		size += 4 // bit(32) OCRResolution;
+		size += 1 // bit(8) timeStampLength;
+		size += 1 // bit(8) OCRLength;
+		size += 1 // bit(8) AU_Length;
+		size += 1 // bit(8) instantBitrateLength;
+		// bit(4) degradationPriorityLength;
+		// bit(5) AU_seqNumLength;
+		// bit(5) packetSeqNumLength;
+		// bit(2) reserved=0b11;
+		size += 2
+		if d.DurationFlag {
+			size += 4 // bit(32) timeScale;
+			size += 2 // bit(16) accessUnitDuration;
+			size += 2 // bit(16) compositionUnitDuration;
+		}
+	}
+	size += uint32(len(d.Remaining))
+	return d.updateHeader(SLConfigDescrTag, size)
+}
+
+func (d *SLConfigDescriptor) DescriptorRead(r io.Reader, header *DescriptorHeader) (err error) {
+	if err = d.ReadHeader(r, header); err != nil {
+		return
+	}
+	payload, err := d.readPayload(r)
+	if err != nil {
+		return
+	}
+	if err = binary.Read(payload, binary.BigEndian, &d.Predefined); err != nil {
+		return
+	}
+	if d.Predefined == SLConfigPredefinedCustom {
+		var tmp [15]uint8
+		if err = binary.Read(payload, binary.BigEndian, &tmp); err != nil {
+			return
+		}
+		d.UseAccessUnitStartFlag = tmp[0]&0x80 > 0
+		d.UseAccessUnitEndFlag = tmp[0]&0x40 > 0
+		d.UseRandomAccessPointFlag = tmp[0]&0x20 > 0
+		d.HasRandomAccessUnitsOnlyFlag = tmp[0]&0x10 > 0
+		d.UsePaddingFlag = tmp[0]&0x08 > 0
+		d.UseTimeStampsFlag = tmp[0]&0x04 > 0
+		d.UseIdleFlag = tmp[0]&0x02 > 0
+		d.DurationFlag = tmp[0]&0x01 > 0
+		d.TimeStampResolution = binary.BigEndian.Uint32(tmp[1:5])
+		d.OCRResolution = binary.BigEndian.Uint32(tmp[5:9])
+		d.TimeStampLength = tmp[9]
+		d.OCRLength = tmp[10]
+		d.AULength = tmp[11]
+		d.InstantBitrateLength = tmp[12]
+		lengths := binary.BigEndian.Uint16(tmp[13:15])
+		d.DegradationPriorityLength = uint8(lengths >> 12)
+		d.AUSeqNumLength = uint8(lengths>>7) & 0x1F
+		d.PacketSeqNumLength = uint8(lengths>>2) & 0x1F
+		if d.DurationFlag {
+			if err = binary.Read(payload, binary.BigEndian, &d.TimeScale); err != nil {
+				return
+			}
+			if err = binary.Read(payload, binary.BigEndian, &d.AccessUnitDuration); err != nil {
+				return
+			}
+			if err = binary.Read(payload, binary.BigEndian, &d.CompositionUnitDuration); err != nil {
+				return
+			}
+		}
+	}
+	d.Remaining = make([]byte, payload.Len())
+	if _, err = io.ReadFull(payload, d.Remaining); err != nil {
+		return
+	}
+	return
+}
+
+func (d *SLConfigDescriptor) DescriptorWrite(w io.Writer) (err error) {
+	if err = d.WriteHeader(w); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, d.Predefined); err != nil {
+		return
+	}
+	if d.Predefined == SLConfigPredefinedCustom {
+		var tmp [15]uint8
+		for i, flag := range []bool{
+			d.UseAccessUnitStartFlag,
+			d.UseAccessUnitEndFlag,
+			d.UseRandomAccessPointFlag,
+			d.HasRandomAccessUnitsOnlyFlag,
+			d.UsePaddingFlag,
+			d.UseTimeStampsFlag,
+			d.UseIdleFlag,
+			d.DurationFlag,
+		} {
+			if flag {
+				tmp[0] |= 0x80 >> i
+			}
+		}
+		binary.BigEndian.PutUint32(tmp[1:5], d.TimeStampResolution)
+		binary.BigEndian.PutUint32(tmp[5:9], d.OCRResolution)
+		tmp[9] = d.TimeStampLength
+		tmp[10] = d.OCRLength
+		tmp[11] = d.AULength
+		tmp[12] = d.InstantBitrateLength
+		lengths := uint16(d.DegradationPriorityLength&0xF)<<12 | uint16(d.AUSeqNumLength&0x1F)<<7 | uint16(d.PacketSeqNumLength&0x1F)<<2 | 0b11
+		binary.BigEndian.PutUint16(tmp[13:15], lengths)
+		if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+			return
+		}
+		if d.DurationFlag {
+			if err = binary.Write(w, binary.BigEndian, d.TimeScale); err != nil {
+				return
+			}
+			if err = binary.Write(w, binary.BigEndian, d.AccessUnitDuration); err != nil {
+				return
+			}
+			if err = binary.Write(w, binary.BigEndian, d.CompositionUnitDuration); err != nil {
+				return
+			}
+		}
+	}
+	if _, err = w.Write(d.Remaining); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package mp4
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestElementaryStreamDescriptorBox(t *testing.T) {
+	esds := &ElementaryStreamDescriptorBox{ESDescriptor: ESDescriptor{
+		ESID: 1,
+		DecoderConfig: &DecoderConfigDescriptor{
+			ObjectTypeIndication: 0x40,
+			StreamType:           StreamTypeAudio,
+			DecSpecificInfo:      &DecoderSpecificInfo{Info: []byte{0x12, 0x10}},
+		},
+		SLConfig: &SLConfigDescriptor{Predefined: 2},
+	}}
+	var buf bytes.Buffer
+	esds.Mp4BoxUpdate()
+	if err := esds.Mp4BoxWrite(&buf); err != nil {
+		t.Fatal(err)
+	}
+	box, err := ReadBox(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := box.(*ElementaryStreamDescriptorBox).ESDescriptor.DecoderConfig
+	if config == nil || config.DecSpecificInfo == nil || !bytes.Equal(config.DecSpecificInfo.Info, []byte{0x12, 0x10}) {
+		t.Errorf("esds box is read as %+v", box)
+	}
+}
+
+func TestElementaryStreamDescriptorBoxInvalidSize(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data []byte
+	}{
+		// an ES_Descriptor of 0x0FFFFFFF bytes in a box of 17 bytes.
+		{"descriptor larger than box", []byte{
+			0, 0, 0, 17, 'e', 's', 'd', 's', 0, 0, 0, 0,
+			0x03, 0xFF, 0xFF, 0xFF, 0x7F,
+		}},
+		// a DecoderConfigDescriptor of 0x0FFFFFFF bytes in an ES_Descriptor
+		// of 8 bytes.
+		{"descriptor larger than parent", []byte{
+			0, 0, 0, 22, 'e', 's', 'd', 's', 0, 0, 0, 0,
+			0x03, 0x08, 0x00, 0x01, 0x00,
+			0x04, 0xFF, 0xFF, 0xFF, 0x7F,
+		}},
+		// an unknown descriptor of 0x0FFFFFFF bytes in an ES_Descriptor of 8
+		// bytes.
+		{"unknown descriptor larger than parent", []byte{
+			0, 0, 0, 22, 'e', 's', 'd', 's', 0, 0, 0, 0,
+			0x03, 0x08, 0x00, 0x01, 0x00,
+			0x7E, 0xFF, 0xFF, 0xFF, 0x7F,
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ReadBox(bytes.NewReader(test.data)); !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("got error %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package mp4
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// RelocateChunkOffsets replaces each chunk offset of the tracks of the movie
+// with the offset returned by relocate. The ‘stco’ box of a track is replaced
+// by a ‘co64’ box in place if one of its new offsets does not fit into 32
+// bits.
+func (b *MovieBox) RelocateChunkOffsets(relocate func(offset uint64) uint64) (err error) {
+	for _, trak := range b.Tracks() {
+		stbl := trak.SampleTable()
+		if stbl == nil {
+			continue
+		}
+		var offsets []uint64
+		if offsets, err = chunkOffsets(stbl); err != nil {
+			return
+		}
+		for i, offset := range offsets {
+			offsets[i] = relocate(offset)
+		}
+		setChunkOffsets(stbl, offsets)
+	}
+	return
+}
+
+// setChunkOffsets stores the offsets in the ‘co64’ box or the ‘stco’ box of
+// the sample table, replacing the ‘stco’ box by a ‘co64’ box if an offset does
+// not fit into 32 bits.
+func setChunkOffsets(stbl *SampleTableBox, offsets []uint64) {
+	if co64, ok := stbl.Mp4BoxFindFirst(Co64BoxType).(*ChunkLargeOffsetBox); ok {
+		co64.Entries = make([]ChunkLargeOffsetEntry, len(offsets))
+		for i, offset := range offsets {
+			co64.Entries[i].ChunkOffset = offset
+		}
+		return
+	}
+	box := newChunkOffsetBox(offsets)
+	children := stbl.Mp4BoxChildren()
+	for i, child := range children {
+		if child.Mp4BoxType() == StcoBoxType {
+			children[i] = box
+			stbl.Mp4BoxReplaceChildren(children)
+			return
+		}
+	}
+	stbl.Mp4BoxAppend(box)
+}
+
+// WriteFaststart writes the file the movie was read from with the movie box
+// placed before the first media data box, so that playback can start before
+// the whole file is downloaded. The movie box may have been edited since it
+// was read, e.g. to add metadata; the chunk offsets are relocated for the new
+// layout, upgrading to ‘co64’ where needed. All other top‐level boxes are
+// copied from r, which must give access to the file the movie was read from,
+// so that the media data is streamed rather than held in memory. The offsets
+// are relocated in a copy of the movie box, so the movie still describes r.
+func (m *Movie) WriteFaststart(w io.Writer, r io.ReaderAt) (err error) {
+	if len(m.Fragments) > 0 {
+		err = fmt.Errorf("movie is fragmented: %w", ErrInvalidFormat)
+		return
+	}
+	// the other boxes keep their order, and the movie box is inserted before
+	// the box at index insert.
+	var boxes []FileBox
+	insert := -1
+	for _, box := range m.Boxes {
+		if box.Type == MoovBoxType {
+			continue
+		}
+		if box.Type == MdatBoxType && insert < 0 {
+			insert = len(boxes)
+		}
+		boxes = append(boxes, box)
+	}
+	if insert < 0 {
+		insert = len(boxes)
+	}
+
+	var moov *MovieBox
+	if moov, err = cloneMovieBox(m.Moov); err != nil {
+		return
+	}
+	moovSize := uint64(moov.Mp4BoxUpdate())
+	offsets := make([]uint64, len(boxes))
+	var moovOffset, offset uint64
+	for i, box := range boxes {
+		if i == insert {
+			moovOffset = offset
+			offset += moovSize
+		}
+		offsets[i] = offset
+		offset += box.Size
+	}
+	if insert == len(boxes) {
+		moovOffset = offset
+	}
+	if err = moov.RelocateChunkOffsets(func(offset uint64) uint64 {
+		for i, box := range boxes {
+			if offset >= box.Offset && (box.Size == 0 || offset < box.Offset+box.Size) {
+				return offsets[i] + offset - box.Offset
+			}
+		}
+		return offset
+	}); err != nil {
+		return
+	}
+	if err = fitMovieBox(moov, moovOffset, moovSize); err != nil {
+		return
+	}
+
+	for i, box := range boxes {
+		if i == insert {
+			if err = moov.Mp4BoxWrite(w); err != nil {
+				return
+			}
+		}
+		if err = copyFileBox(w, r, box); err != nil {
+			return
+		}
+	}
+	if insert == len(boxes) {
+		if err = moov.Mp4BoxWrite(w); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// fitMovieBox updates the size of the movie box at moovOffset, whose chunk
+// offsets were relocated for a movie box of moovSize bytes. Upgrading to
+// ‘co64’ grows the movie box, so the chunk offsets following it are moved by
+// the growth until its size settles.
+func fitMovieBox(moov *MovieBox, moovOffset, moovSize uint64) (err error) {
+	for {
+		size := uint64(moov.Mp4BoxUpdate())
+		if size == moovSize {
+			return
+		}
+		growth := size - moovSize
+		if err = moov.RelocateChunkOffsets(func(offset uint64) uint64 {
+			if offset >= moovOffset {
+				return offset + growth
+			}
+			return offset
+		}); err != nil {
+			return
+		}
+		moovSize = size
+	}
+}
+
+// cloneMovieBox returns a deep copy of the movie box, made by writing it and
+// reading it back.
+func cloneMovieBox(moov *MovieBox) (clone *MovieBox, err error) {
+	var buf bytes.Buffer
+	moov.Mp4BoxUpdate()
+	if err = moov.Mp4BoxWrite(&buf); err != nil {
+		return
+	}
+	var box Box
+	if box, err = ReadBox(&buf); err != nil {
+		return
+	}
+	clone = box.(*MovieBox)
+	return
+}
+
+// copyFileBox copies the top‐level box from r to w.
+func copyFileBox(w io.Writer, r io.ReaderAt, box FileBox) (err error) {
+	size := int64(box.Size)
+	if box.Size == 0 {
+		size = math.MaxInt64 - int64(box.Offset)
+	}
+	var n int64
+	if n, err = io.Copy(w, io.NewSectionReader(r, int64(box.Offset), size)); err != nil {
+		return
+	}
+	if box.Size != 0 && n != size {
+		err = fmt.Errorf("%s box at %d is truncated: %w", box.Type, box.Offset, io.ErrUnexpectedEOF)
+	}
+	return
+}
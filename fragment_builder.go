@@ -0,0 +1,398 @@
+package mp4
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// MediaSample is a sample together with its data, as added to a
+// FragmentBuilder.
+type MediaSample struct {
+	Data              []byte
+	Duration          uint32
+	CompositionOffset int64
+
+	// the sample is a sync sample unless SampleIsNonSyncSample is set.
+	Flags SampleFlags
+
+	// a SampleDescriptionIndex of 0 means 1.
+	SampleDescriptionIndex uint32
+
+	// holds the initialization vector and the subsample map of an encrypted
+	// sample, or is nil if the sample is not encrypted.
+	Encryption *SampleEncryptionSampleEntry
+
+	// lists the groups of the sample groupings that the sample belongs to.
+	Groups []MediaSampleGroup
+}
+
+// MediaSampleGroup assigns a sample to a group of a sample grouping. The
+// groups are described by the ‘sgpd’ box of the grouping in the sample table
+// of the track.
+type MediaSampleGroup struct {
+	GroupingType          FourCC
+	GroupingTypeParameter uint32
+
+	// is the 1‐based index of the group entry in the ‘sgpd’ box.
+	GroupDescriptionIndex uint32
+}
+
+// FragmentBuilder collects the samples of the tracks of a fragmented movie and
+// builds movie fragments holding them. Each fragment has a track fragment for
+// every track with samples, or one for every run of samples using the same
+// sample entry and either all encrypted or all clear, with a ‘tfdt’ box and a
+// single ‘trun’ box whose data offset is relative to the movie fragment box.
+// Defaults are placed in the ‘tfhd’ box unless the ‘trex’ box of the track
+// already supplies them. Encrypted samples are described by ‘senc’, ‘saiz’ and
+// ‘saio’ boxes, and the groups of the samples by an ‘sbgp’ box for each sample
+// grouping.
+type FragmentBuilder struct {
+	// the sequence number of the next movie fragment, starting at 1.
+	SequenceNumber uint32
+
+	tracks []*fragmentTrack
+}
+
+type fragmentTrack struct {
+	trex       TrackExtendsBox
+	decodeTime uint64
+	samples    []MediaSample
+}
+
+// NewFragmentBuilder returns a builder for fragments of the tracks of moov,
+// which is the movie box of the initialization segment. The decode time of
+// each track starts at 0.
+func NewFragmentBuilder(moov *MovieBox) *FragmentBuilder {
+	b := &FragmentBuilder{SequenceNumber: 1}
+	for _, trak := range moov.Tracks() {
+		tkhd := trak.TrackHeader()
+		if tkhd == nil {
+			continue
+		}
+		track := &fragmentTrack{trex: TrackExtendsBox{TrackID: tkhd.TrackID}}
+		if trex := moov.TrackExtends(tkhd.TrackID); trex != nil {
+			track.trex = *trex
+		}
+		b.tracks = append(b.tracks, track)
+	}
+	return b
+}
+
+func (b *FragmentBuilder) track(trackID uint32) (track *fragmentTrack, err error) {
+	for _, track = range b.tracks {
+		if track.trex.TrackID == trackID {
+			return
+		}
+	}
+	err = fmt.Errorf("track %d is not in the movie: %w", trackID, ErrInvalidFormat)
+	return
+}
+
+// SetDecodeTime sets the decode time of the first sample of the track in the
+// next movie fragment.
+func (b *FragmentBuilder) SetDecodeTime(trackID uint32, decodeTime uint64) (err error) {
+	var track *fragmentTrack
+	if track, err = b.track(trackID); err != nil {
+		return
+	}
+	track.decodeTime = decodeTime
+	return
+}
+
+// AddSample appends a sample to the track in the next movie fragment.
+func (b *FragmentBuilder) AddSample(trackID uint32, sample MediaSample) (err error) {
+	var track *fragmentTrack
+	if track, err = b.track(trackID); err != nil {
+		return
+	}
+	if sample.SampleDescriptionIndex == 0 {
+		sample.SampleDescriptionIndex = 1
+	}
+	track.samples = append(track.samples, sample)
+	return
+}
+
+// Fragment returns the movie fragment box and the media data box holding the
+// samples added since the previous fragment, with the data offsets set for the
+// media data box immediately following the movie fragment box. The sequence
+// number and the decode times then advance to the next fragment.
+func (b *FragmentBuilder) Fragment() (moof *MovieFragmentBox, mdat *MediaDataBox, err error) {
+	moof = &MovieFragmentBox{}
+	moof.Mp4BoxAppend(&MovieFragmentHeaderBox{SequenceNumber: b.SequenceNumber})
+
+	type trackRun struct {
+		traf      *TrackFragmentBox
+		trun      *TrackRunBox
+		saio      *SampleAuxiliaryInformationOffsetsBox
+		samples   []MediaSample
+		dataStart uint64
+	}
+	var (
+		runs     []trackRun
+		dataSize uint64
+	)
+	for _, track := range b.tracks {
+		samples := track.samples
+		for len(samples) > 0 {
+			n := 1
+			// clear samples have no initialization vector, whose size in
+			// the ‘senc’ box is the same for all samples, so they are not
+			// in the track fragment of encrypted samples.
+			for n < len(samples) && samples[n].SampleDescriptionIndex == samples[0].SampleDescriptionIndex &&
+				(samples[n].Encryption == nil) == (samples[0].Encryption == nil) {
+				n++
+			}
+			run := trackRun{samples: samples[:n], dataStart: dataSize}
+			run.traf, run.trun, run.saio = newTrackFragment(&track.trex, track.decodeTime, run.samples)
+			moof.Mp4BoxAppend(run.traf)
+			runs = append(runs, run)
+			for _, sample := range run.samples {
+				dataSize += uint64(len(sample.Data))
+				track.decodeTime += uint64(sample.Duration)
+			}
+			samples = samples[n:]
+		}
+	}
+
+	mdat = &MediaDataBox{}
+	moofSize := uint64(moof.Mp4BoxUpdate())
+	if moofSize+uint64(mdat.HeaderSize())+dataSize > 0xFFFFFFFF {
+		err = fmt.Errorf("movie fragment data of %d bytes is too large: %w", dataSize, ErrInvalidFormat)
+		return
+	}
+	// the data offsets of the track runs are signed 32‐bit integers.
+	if n := len(runs); n > 0 && moofSize+uint64(mdat.HeaderSize())+runs[n-1].dataStart > math.MaxInt32 {
+		err = fmt.Errorf("track run data at %d bytes exceeds the data offset range: %w", runs[n-1].dataStart, ErrInvalidFormat)
+		return
+	}
+	mdat.Data = make([]byte, 0, dataSize)
+	for _, run := range runs {
+		run.trun.DataOffset = int32(moofSize + uint64(mdat.HeaderSize()) + run.dataStart)
+		if run.saio != nil {
+			run.saio.Offsets[0], _ = sampleEncryptionOffset(moof, run.traf)
+		}
+		for _, sample := range run.samples {
+			mdat.Data = append(mdat.Data, sample.Data...)
+		}
+	}
+	mdat.Mp4BoxUpdate()
+
+	for _, track := range b.tracks {
+		track.samples = nil
+	}
+	b.SequenceNumber++
+	return
+}
+
+// WriteFragment writes the movie fragment box and the media data box returned
+// by Fragment.
+func (b *FragmentBuilder) WriteFragment(w io.Writer) (err error) {
+	var (
+		moof *MovieFragmentBox
+		mdat *MediaDataBox
+	)
+	if moof, mdat, err = b.Fragment(); err != nil {
+		return
+	}
+	if err = moof.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	if err = mdat.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	return
+}
+
+// newTrackFragment returns a track fragment describing the samples, which all
+// use the same sample entry and are either all encrypted or all clear, and its
+// track run and, if the samples are encrypted, its sample auxiliary
+// information offsets box. The data offset of the run
+// and the auxiliary information offset are left to the caller.
+func newTrackFragment(trex *TrackExtendsBox, decodeTime uint64, samples []MediaSample) (traf *TrackFragmentBox, trun *TrackRunBox, saio *SampleAuxiliaryInformationOffsetsBox) {
+	tfhd := &TrackFragmentHeaderBox{TrackID: trex.TrackID}
+	tfhdFlags := FLAG_TFHD_DEFAULT_BASE_IS_MOOF
+	trun = &TrackRunBox{Samples: make([]TrackRunSampleEntry, len(samples))}
+	trunFlags := FLAG_TRUN_DATA_OFFSET
+
+	if desc := samples[0].SampleDescriptionIndex; desc != trex.DefaultSampleDescrptionIndex {
+		tfhd.SampleDescrptionIndex = desc
+		tfhdFlags |= FLAG_TFHD_SAMPLE_DESCRIPTION_INDEX
+	}
+
+	sameDuration, sameSize, sameFlags, sameOtherFlags := true, true, true, true
+	for i, sample := range samples {
+		sameDuration = sameDuration && sample.Duration == samples[0].Duration
+		sameSize = sameSize && len(sample.Data) == len(samples[0].Data)
+		sameFlags = sameFlags && sample.Flags == samples[0].Flags
+		if i > 0 {
+			sameOtherFlags = sameOtherFlags && sample.Flags == samples[1].Flags
+		}
+		trun.Samples[i] = TrackRunSampleEntry{
+			SampleDuration:              sample.Duration,
+			SampleSize:                  uint32(len(sample.Data)),
+			SampleFlags:                 sample.Flags,
+			SampleCompositionTimeOffset: sample.CompositionOffset,
+		}
+		if sample.CompositionOffset != 0 {
+			trunFlags |= FLAG_TRUN_SAMPLE_COMPOSITION_TIME_OFFSET
+		}
+		if sample.CompositionOffset < 0 {
+			trun.Version = 1
+		}
+	}
+
+	switch {
+	case !sameDuration:
+		trunFlags |= FLAG_TRUN_SAMPLE_DURATION
+	case samples[0].Duration != trex.DefaultSampleDuration:
+		tfhd.DefaultSampleDuration = samples[0].Duration
+		tfhdFlags |= FLAG_TFHD_DEFAULT_SAMPLE_DURATION
+	}
+	switch size := uint32(len(samples[0].Data)); {
+	case !sameSize:
+		trunFlags |= FLAG_TRUN_SAMPLE_SIZE
+	case size != trex.DefaultSampleSize:
+		tfhd.DefaultSampleSize = size
+		tfhdFlags |= FLAG_TFHD_DEFAULT_SAMPLE_SIZE
+	}
+	// the flags of the other samples become the default when only the first
+	// sample differs, as with a sync sample followed by difference samples.
+	defaultFlags := samples[0].Flags
+	switch {
+	case sameFlags:
+	case sameOtherFlags:
+		defaultFlags = samples[1].Flags
+		trun.FirstSampleFlags = samples[0].Flags
+		trunFlags |= FLAG_TRUN_FIRST_SAMPLE_FLAGS
+	default:
+		trunFlags |= FLAG_TRUN_SAMPLE_FLAGS
+	}
+	if trunFlags&FLAG_TRUN_SAMPLE_FLAGS == 0 && defaultFlags != trex.DefaultSampleFlags {
+		tfhd.DefaultSampleFlags = defaultFlags
+		tfhdFlags |= FLAG_TFHD_DEFAULT_SAMPLE_FLAGS
+	}
+	tfhd.Mp4BoxSetFlags(tfhdFlags)
+	trun.Mp4BoxSetFlags(trunFlags)
+
+	tfdt := &TrackFragmentBaseMediaDecodeTimeBox{BaseMediaDecodeTime: decodeTime}
+	if decodeTime > 0xFFFFFFFF {
+		tfdt.Version = 1
+	}
+
+	traf = &TrackFragmentBox{}
+	traf.Mp4BoxAppend(tfhd)
+	traf.Mp4BoxAppend(tfdt)
+	if senc, saiz := newSampleEncryption(samples); senc != nil {
+		saio = &SampleAuxiliaryInformationOffsetsBox{Offsets: []uint64{0}}
+		traf.Mp4BoxAppend(saiz)
+		traf.Mp4BoxAppend(saio)
+		traf.Mp4BoxAppend(senc)
+	}
+	for _, sbgp := range newSampleToGroups(samples) {
+		traf.Mp4BoxAppend(sbgp)
+	}
+	traf.Mp4BoxAppend(trun)
+	return
+}
+
+// newSampleEncryption returns the sample encryption box and the sample
+// auxiliary information sizes box describing the samples, or nil if the
+// samples are clear. The samples must be either all encrypted or all clear.
+func newSampleEncryption(samples []MediaSample) (senc *SampleEncryptionBox, saiz *SampleAuxiliaryInformationSizesBox) {
+	if samples[0].Encryption == nil {
+		return
+	}
+	subsamples := false
+	for _, sample := range samples {
+		subsamples = subsamples || len(sample.Encryption.Subsamples) > 0
+	}
+	senc = &SampleEncryptionBox{Samples: make([]SampleEncryptionSampleEntry, len(samples))}
+	if subsamples {
+		senc.Mp4BoxSetFlags(FLAG_SENC_USE_SUBSAMPLE_ENCRYPTION)
+	}
+	saiz = &SampleAuxiliaryInformationSizesBox{SampleInfoSizes: make([]uint8, len(samples))}
+	sameSize := true
+	for i, sample := range samples {
+		senc.Samples[i] = *sample.Encryption
+		size := len(senc.Samples[i].InitializationVector)
+		if subsamples {
+			size += 2 + 6*len(senc.Samples[i].Subsamples)
+		}
+		saiz.SampleInfoSizes[i] = uint8(size)
+		sameSize = sameSize && saiz.SampleInfoSizes[i] == saiz.SampleInfoSizes[0]
+	}
+	if sameSize && saiz.SampleInfoSizes[0] != 0 {
+		saiz.DefaultSampleInfoSize = saiz.SampleInfoSizes[0]
+		saiz.SampleCount = uint32(len(samples))
+		saiz.SampleInfoSizes = nil
+	}
+	return
+}
+
+// newSampleToGroups returns a sample to group box for each sample grouping
+// that one of the samples belongs to, in the order in which the groupings
+// first appear. Samples that are not in a group of a grouping have the group
+// description index 0.
+func newSampleToGroups(samples []MediaSample) (boxes []*SampleToGroupBox) {
+	for _, sample := range samples {
+	groups:
+		for _, group := range sample.Groups {
+			for _, sbgp := range boxes {
+				if sbgp.GroupingType == group.GroupingType && sbgp.GroupingTypeParameter == group.GroupingTypeParameter {
+					continue groups
+				}
+			}
+			sbgp := &SampleToGroupBox{GroupingType: group.GroupingType, GroupingTypeParameter: group.GroupingTypeParameter}
+			if group.GroupingTypeParameter != 0 {
+				sbgp.Version = 1
+			}
+			boxes = append(boxes, sbgp)
+		}
+	}
+	for _, sbgp := range boxes {
+		for _, sample := range samples {
+			var index uint32
+			for _, group := range sample.Groups {
+				if group.GroupingType == sbgp.GroupingType && group.GroupingTypeParameter == sbgp.GroupingTypeParameter {
+					index = group.GroupDescriptionIndex
+					break
+				}
+			}
+			if n := len(sbgp.Entries); n > 0 && sbgp.Entries[n-1].GroupDescriptionIndex == index {
+				sbgp.Entries[n-1].SampleCount++
+			} else {
+				sbgp.Entries = append(sbgp.Entries, SampleToGroupEntry{SampleCount: 1, GroupDescriptionIndex: index})
+			}
+		}
+	}
+	return
+}
+
+// sampleEncryptionOffset returns the position, relative to the start of the
+// movie fragment box, of the auxiliary information held by the sample
+// encryption box of the track fragment. ok is false if the track fragment has
+// no sample encryption box. The sizes of the boxes must be up‐to‐date.
+func sampleEncryptionOffset(moof *MovieFragmentBox, traf *TrackFragmentBox) (offset uint64, ok bool) {
+	offset = uint64(moof.HeaderSize())
+	for _, child := range moof.Mp4BoxChildren() {
+		if child == Box(traf) {
+			break
+		}
+		offset += uint64(child.Mp4BoxSize())
+	}
+	offset += uint64(traf.HeaderSize())
+	for _, child := range traf.Mp4BoxChildren() {
+		if senc, found := child.(*SampleEncryptionBox); found {
+			// the auxiliary information follows the sample count, and the
+			// algorithm, IV size and KID if these are overridden.
+			offset += uint64(senc.headerSize()) + 4
+			if senc.Mp4BoxFlags()&FLAG_SENC_OVERRIDE_TRACK_ENCRYPTION_BOX_PARAMS > 0 {
+				offset += 3 + 1 + 16
+			}
+			return offset, true
+		}
+		offset += uint64(child.Mp4BoxSize())
+	}
+	return 0, false
+}
@@ -0,0 +1,60 @@
+package mp4
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFragmentBuilderClearAndEncryptedSamples(t *testing.T) {
+	moov := newTestFragmentedMovie()
+	b := NewFragmentBuilder(moov)
+	encryption := []*SampleEncryptionSampleEntry{
+		nil,
+		{InitializationVector: bytes.Repeat([]byte{1}, 8)},
+		{InitializationVector: bytes.Repeat([]byte{2}, 8)},
+		nil,
+	}
+	var want [][]byte
+	for i, e := range encryption {
+		data := bytes.Repeat([]byte{byte('0' + i)}, 4+i)
+		if err := b.AddSample(1, MediaSample{Data: data, Duration: 40, Encryption: e}); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, data)
+	}
+	var buf bytes.Buffer
+	if err := b.WriteFragment(&buf); err != nil {
+		t.Fatal(err)
+	}
+	file := buf.Bytes()
+	moof := readTestFragment(t, file, 0)
+	checkTestFragmentSamples(t, moov, moof, file, 0, want)
+
+	// the clear and the encrypted samples are in separate track fragments,
+	// which follow each other on the media timeline.
+	trafs := moof.TrackFragments()
+	if len(trafs) != 3 {
+		t.Fatalf("movie fragment has %d track fragments, want 3", len(trafs))
+	}
+	for i, traf := range trafs {
+		tfdt, ok := traf.Mp4BoxFindFirst(TfdtBoxType).(*TrackFragmentBaseMediaDecodeTimeBox)
+		if !ok || tfdt.BaseMediaDecodeTime != []uint64{0, 40, 120}[i] {
+			t.Errorf("track fragment %d has an invalid tfdt box", i)
+		}
+		senc, ok := traf.Mp4BoxFindFirst(SencBoxType).(*SampleEncryptionBox)
+		if i != 1 {
+			if ok {
+				t.Errorf("track fragment %d of clear samples has a senc box", i)
+			}
+			continue
+		}
+		if !ok || len(senc.Samples) != 2 {
+			t.Fatalf("track fragment %d has no senc box with 2 samples", i)
+		}
+		for j, sample := range senc.Samples {
+			if !bytes.Equal(sample.InitializationVector, encryption[1+j].InitializationVector) {
+				t.Errorf("sample %d has initialization vector %x, want %x", j, sample.InitializationVector, encryption[1+j].InitializationVector)
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package mp4
+
+import (
+	"fmt"
+)
+
+// FragmentSample is a sample of a movie fragment with all defaults resolved.
+// Number counts the samples of the track fragment from 1.
+type FragmentSample struct {
+	Sample
+
+	TrackID uint32
+	Flags   SampleFlags
+}
+
+// FragmentSampleIterator walks the samples of the track fragments of a movie
+// fragment in order. Each value is resolved from the ‘trun’ box, falling back
+// to the defaults of the ‘tfhd’ box and then to those of the ‘trex’ box of the
+// initialization movie box.
+type FragmentSampleIterator struct {
+	samples []FragmentSample
+	index   int
+}
+
+// NewFragmentSampleIterator resolves the samples of the movie fragment. moov
+// is the movie box of the initialization segment, which supplies the track
+// extends defaults and may be nil if every field is given by the fragment.
+// moofOffset is the file position of the first byte of the movie fragment box,
+// against which sample offsets that are not given by an explicit base data
+// offset are resolved; with a moofOffset of 0 these are relative to the movie
+// fragment box. Decode times start at the base media decode time of the
+// ‘tfdt’ box, or at 0 if there is none.
+func NewFragmentSampleIterator(moov *MovieBox, moof *MovieFragmentBox, moofOffset uint64) (it *FragmentSampleIterator, err error) {
+	it = &FragmentSampleIterator{}
+	var trafs [][]FragmentSample
+	if trafs, err = resolveTrackFragments(moov, moof, moofOffset); err != nil {
+		return
+	}
+	for _, samples := range trafs {
+		it.samples = append(it.samples, samples...)
+	}
+	return
+}
+
+// resolveTrackFragments resolves the samples of each track fragment of the
+// movie fragment, in the order of the track fragments.
+func resolveTrackFragments(moov *MovieBox, moof *MovieFragmentBox, moofOffset uint64) (trafs [][]FragmentSample, err error) {
+	// the end of the data of the preceding track fragment, which is the
+	// implicit base data offset of the next one.
+	dataEnd := moofOffset
+	for _, traf := range moof.TrackFragments() {
+		tfhd := traf.TrackFragmentHeader()
+		if tfhd == nil {
+			err = fmt.Errorf("traf box has no tfhd box: %w", ErrInvalidFormat)
+			return
+		}
+		var trex TrackExtendsBox
+		if moov != nil {
+			if box := moov.TrackExtends(tfhd.TrackID); box != nil {
+				trex = *box
+			}
+		}
+		flags := tfhd.Mp4BoxFlags()
+
+		descIndex := trex.DefaultSampleDescrptionIndex
+		if flags&FLAG_TFHD_SAMPLE_DESCRIPTION_INDEX != 0 {
+			descIndex = tfhd.SampleDescrptionIndex
+		}
+		defaultDuration := trex.DefaultSampleDuration
+		if flags&FLAG_TFHD_DEFAULT_SAMPLE_DURATION != 0 {
+			defaultDuration = tfhd.DefaultSampleDuration
+		}
+		defaultSize := trex.DefaultSampleSize
+		if flags&FLAG_TFHD_DEFAULT_SAMPLE_SIZE != 0 {
+			defaultSize = tfhd.DefaultSampleSize
+		}
+		defaultFlags := trex.DefaultSampleFlags
+		if flags&FLAG_TFHD_DEFAULT_SAMPLE_FLAGS != 0 {
+			defaultFlags = tfhd.DefaultSampleFlags
+		}
+
+		baseDataOffset := trackFragmentBase(tfhd, moofOffset, dataEnd)
+		decodeTime, _ := traf.BaseMediaDecodeTime()
+		offset := baseDataOffset
+		var (
+			number  uint32
+			samples []FragmentSample
+		)
+		for _, trun := range traf.TrackRuns() {
+			trunFlags := trun.Mp4BoxFlags()
+			if trunFlags&FLAG_TRUN_DATA_OFFSET != 0 {
+				if trun.DataOffset < 0 && uint64(-int64(trun.DataOffset)) > baseDataOffset {
+					err = fmt.Errorf("trun data offset %d precedes the file: %w", trun.DataOffset, ErrInvalidFormat)
+					return
+				}
+				offset = uint64(int64(baseDataOffset) + int64(trun.DataOffset))
+			}
+			for i, entry := range trun.Samples {
+				number++
+				sample := FragmentSample{
+					Sample: Sample{
+						Number:                 number,
+						DecodeTime:             decodeTime,
+						Duration:               defaultDuration,
+						Size:                   defaultSize,
+						Offset:                 offset,
+						SampleDescriptionIndex: descIndex,
+					},
+					TrackID: tfhd.TrackID,
+					Flags:   defaultFlags,
+				}
+				if trunFlags&FLAG_TRUN_SAMPLE_DURATION != 0 {
+					sample.Duration = entry.SampleDuration
+				}
+				if trunFlags&FLAG_TRUN_SAMPLE_SIZE != 0 {
+					sample.Size = entry.SampleSize
+				}
+				if trunFlags&FLAG_TRUN_SAMPLE_FLAGS != 0 {
+					sample.Flags = entry.SampleFlags
+				} else if i == 0 && trunFlags&FLAG_TRUN_FIRST_SAMPLE_FLAGS != 0 {
+					sample.Flags = trun.FirstSampleFlags
+				}
+				if trunFlags&FLAG_TRUN_SAMPLE_COMPOSITION_TIME_OFFSET != 0 {
+					sample.CompositionOffset = entry.SampleCompositionTimeOffset
+				}
+				sample.IsSync = !sample.Flags.SampleIsNonSyncSample
+				samples = append(samples, sample)
+				decodeTime += uint64(sample.Duration)
+				offset += uint64(sample.Size)
+			}
+		}
+		trafs = append(trafs, samples)
+		dataEnd = offset
+	}
+	return
+}
+
+// trackFragmentBase returns the base data offset of a track fragment of the
+// movie fragment at moofOffset, where dataEnd is the end of the data of the
+// preceding track fragment, or moofOffset for the first one.
+func trackFragmentBase(tfhd *TrackFragmentHeaderBox, moofOffset, dataEnd uint64) uint64 {
+	flags := tfhd.Mp4BoxFlags()
+	switch {
+	case flags&FLAG_TFHD_BASE_DATA_OFFSET != 0:
+		return tfhd.BaseDataOffset
+	case flags&FLAG_TFHD_DEFAULT_BASE_IS_MOOF != 0:
+		return moofOffset
+	default:
+		return dataEnd
+	}
+}
+
+// SampleCount returns the number of samples of all track fragments.
+func (it *FragmentSampleIterator) SampleCount() int {
+	return len(it.samples)
+}
+
+// Next returns the next sample, or false once all samples are returned.
+func (it *FragmentSampleIterator) Next() (sample FragmentSample, ok bool) {
+	if it.index >= len(it.samples) {
+		return
+	}
+	sample = it.samples[it.index]
+	it.index++
+	ok = true
+	return
+}
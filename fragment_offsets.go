@@ -0,0 +1,86 @@
+package mp4
+
+import (
+	"fmt"
+)
+
+// UpdateDataOffsets updates the sizes of the movie fragment box, like
+// Mp4BoxUpdate, and then fixes the offsets that its edited contents have made
+// stale. It must be called in place of Mp4BoxUpdate after boxes are added to
+// or removed from a movie fragment box whose Size is still that of the box
+// the offsets were written for, as after reading it.
+//
+// moofOffset is the file position of the movie fragment box and moov is the
+// movie box of the initialization segment, as for NewFragmentSampleIterator.
+// Data following the old end of the movie fragment box is taken to move by
+// the change in size. The data offsets of the track runs are recomputed
+// against the base data offset of their track fragment, which is the movie
+// fragment box if FLAG_TFHD_DEFAULT_BASE_IS_MOOF is set, or the explicit
+// BaseDataOffset, which moves along with the data it points to. A ‘saio’ box
+// with a single offset into the movie fragment box is pointed at the
+// auxiliary information of the ‘senc’ box of its track fragment.
+func (b *MovieFragmentBox) UpdateDataOffsets(moov *MovieBox, moofOffset uint64) (err error) {
+	var trafs [][]FragmentSample
+	if trafs, err = resolveTrackFragments(moov, b, moofOffset); err != nil {
+		return
+	}
+	oldEnd := moofOffset + uint64(b.Size)
+	delta := int64(b.Mp4BoxUpdate()) - int64(oldEnd-moofOffset)
+	move := func(pos uint64) uint64 {
+		if pos >= oldEnd {
+			return uint64(int64(pos) + delta)
+		}
+		return pos
+	}
+
+	oldDataEnd, newDataEnd := moofOffset, moofOffset
+	for i, traf := range b.TrackFragments() {
+		tfhd := traf.TrackFragmentHeader()
+		oldBase := trackFragmentBase(tfhd, moofOffset, oldDataEnd)
+		if tfhd.Mp4BoxFlags()&FLAG_TFHD_BASE_DATA_OFFSET != 0 {
+			tfhd.BaseDataOffset = move(tfhd.BaseDataOffset)
+		}
+		newBase := trackFragmentBase(tfhd, moofOffset, newDataEnd)
+
+		for _, trun := range traf.TrackRuns() {
+			if trun.Mp4BoxFlags()&FLAG_TRUN_DATA_OFFSET == 0 {
+				continue
+			}
+			offset := int64(move(uint64(int64(oldBase)+int64(trun.DataOffset)))) - int64(newBase)
+			if offset < -1<<31 || offset >= 1<<31 {
+				err = fmt.Errorf("trun data offset %d does not fit into 32 bits: %w", offset, ErrInvalidFormat)
+				return
+			}
+			trun.DataOffset = int32(offset)
+		}
+
+		for _, box := range traf.Mp4BoxFindAll(SaioBoxType) {
+			saio, ok := box.(*SampleAuxiliaryInformationOffsetsBox)
+			if !ok {
+				continue
+			}
+			for j, offset := range saio.Offsets {
+				pos := oldBase + offset
+				if sencOffset, found := sampleEncryptionOffset(b, traf); found && len(saio.Offsets) == 1 && pos >= moofOffset && pos < oldEnd {
+					pos = moofOffset + sencOffset
+				} else {
+					pos = move(pos)
+				}
+				if pos < newBase || (saio.Version == 0 && pos-newBase > 0xFFFFFFFF) {
+					err = fmt.Errorf("saio offset %d cannot be expressed against base %d: %w", pos, newBase, ErrInvalidFormat)
+					return
+				}
+				saio.Offsets[j] = pos - newBase
+			}
+		}
+
+		if samples := trafs[i]; len(samples) > 0 {
+			last := samples[len(samples)-1].Sample
+			oldDataEnd = last.Offset + uint64(last.Size)
+			newDataEnd = move(last.Offset) + uint64(last.Size)
+		} else {
+			oldDataEnd, newDataEnd = oldBase, newBase
+		}
+	}
+	return
+}
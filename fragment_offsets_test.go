@@ -0,0 +1,145 @@
+package mp4
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newTestFragmentedMovie() *MovieBox {
+	moov := &MovieBox{}
+	moov.Mp4BoxAppend(&MovieHeaderBox{Timescale: 1000})
+	mvex := &MovieExtendsBox{}
+	for _, trackID := range []uint32{1, 2} {
+		trak := &TrackBox{}
+		trak.Mp4BoxAppend(&TrackHeaderBox{TrackID: trackID})
+		moov.Mp4BoxAppend(trak)
+		mvex.Mp4BoxAppend(&TrackExtendsBox{TrackID: trackID, DefaultSampleDescrptionIndex: 1})
+	}
+	moov.Mp4BoxAppend(mvex)
+	return moov
+}
+
+// buildTestFragment returns a file holding prefix bytes of padding followed by
+// a movie fragment of two tracks, the first of them encrypted, and its media
+// data, together with the data of the samples in file order.
+func buildTestFragment(t *testing.T, moov *MovieBox, prefix int) (file []byte, samples [][]byte) {
+	b := NewFragmentBuilder(moov)
+	for i := 0; i < 3; i++ {
+		sample := MediaSample{
+			Data:     bytes.Repeat([]byte{'v', byte('0' + i)}, 5+i),
+			Duration: 40,
+			Encryption: &SampleEncryptionSampleEntry{
+				InitializationVector: bytes.Repeat([]byte{byte(i + 1)}, 8),
+				Subsamples:           []SampleEncryptionSubsampleEntry{{BytesOfClearData: 2, BytesOfProtectedData: uint32(8 + 2*i)}},
+			},
+		}
+		if err := b.AddSample(1, sample); err != nil {
+			t.Fatal(err)
+		}
+		samples = append(samples, sample.Data)
+	}
+	for i := 0; i < 2; i++ {
+		data := []byte{'a', byte('0' + i), 'x'}
+		if err := b.AddSample(2, MediaSample{Data: data, Duration: 1024}); err != nil {
+			t.Fatal(err)
+		}
+		samples = append(samples, data)
+	}
+	buf := bytes.NewBuffer(make([]byte, prefix))
+	if err := b.WriteFragment(buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), samples
+}
+
+// rewriteTestFragment reads the movie fragment at moofOffset of file, edits
+// it, fixes its offsets and returns the file with the edited movie fragment.
+func rewriteTestFragment(t *testing.T, moov *MovieBox, file []byte, moofOffset uint64, edit func(*MovieFragmentBox)) ([]byte, *MovieFragmentBox) {
+	moof := readTestFragment(t, file, moofOffset)
+	oldEnd := moofOffset + uint64(moof.Size)
+	edit(moof)
+	if err := moof.UpdateDataOffsets(moov, moofOffset); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	buf.Write(file[:moofOffset])
+	if err := moof.Mp4BoxWrite(&buf); err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(file[oldEnd:])
+	return buf.Bytes(), moof
+}
+
+func readTestFragment(t *testing.T, file []byte, moofOffset uint64) *MovieFragmentBox {
+	box, err := ReadBox(bytes.NewReader(file[moofOffset:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return box.(*MovieFragmentBox)
+}
+
+// checkTestFragmentSamples resolves the samples of the movie fragment and
+// compares their data in file with want.
+func checkTestFragmentSamples(t *testing.T, moov *MovieBox, moof *MovieFragmentBox, file []byte, moofOffset uint64, want [][]byte) {
+	trafs, err := resolveTrackFragments(moov, moof, moofOffset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]byte
+	for _, samples := range trafs {
+		for _, sample := range samples {
+			if sample.Offset+uint64(sample.Size) > uint64(len(file)) {
+				t.Fatalf("sample at %d of %d bytes is outside of the file", sample.Offset, sample.Size)
+			}
+			got = append(got, file[sample.Offset:sample.Offset+uint64(sample.Size)])
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Errorf("sample %d is %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUpdateDataOffsetsDropAuxiliaryInformation(t *testing.T) {
+	moov := newTestFragmentedMovie()
+	const moofOffset = 100
+	file, want := buildTestFragment(t, moov, moofOffset)
+	checkTestFragmentSamples(t, moov, readTestFragment(t, file, moofOffset), file, moofOffset, want)
+
+	file, moof := rewriteTestFragment(t, moov, file, moofOffset, func(moof *MovieFragmentBox) {
+		for _, traf := range moof.TrackFragments() {
+			for _, boxType := range []BoxType{SencBoxType, SaizBoxType, SaioBoxType} {
+				removeChildren(&traf.Container, boxType, func(Box) bool { return true })
+			}
+		}
+	})
+	checkTestFragmentSamples(t, moov, moof, file, moofOffset, want)
+}
+
+func TestUpdateDataOffsetsAddBox(t *testing.T) {
+	moov := newTestFragmentedMovie()
+	const moofOffset = 24
+	file, want := buildTestFragment(t, moov, moofOffset)
+
+	file, moof := rewriteTestFragment(t, moov, file, moofOffset, func(moof *MovieFragmentBox) {
+		// a box inserted before the track fragments moves the ‘senc’ box
+		// along with the media data.
+		children := append([]Box{&ProtectionSystemSpecificHeaderBox{Data: make([]byte, 37)}}, moof.Mp4BoxChildren()...)
+		moof.Mp4BoxReplaceChildren(children)
+	})
+	checkTestFragmentSamples(t, moov, moof, file, moofOffset, want)
+
+	traf := moof.TrackFragments()[0]
+	saio, ok := traf.Mp4BoxFindFirst(SaioBoxType).(*SampleAuxiliaryInformationOffsetsBox)
+	if !ok || len(saio.Offsets) != 1 {
+		t.Fatal("track fragment has no saio box with a single offset")
+	}
+	iv := file[moofOffset+saio.Offsets[0]:][:8]
+	if !bytes.Equal(iv, bytes.Repeat([]byte{1}, 8)) {
+		t.Errorf("saio points at %x, want the first initialization vector", iv)
+	}
+}
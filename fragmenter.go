@@ -0,0 +1,412 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Fragmenter writes the samples of a movie as a fragmented file, either as a
+// single file or as an initialization segment followed by media segments. The
+// initialization segment holds the movie box with empty sample tables and a
+// movie extends box with a ‘trex’ box for each track and a ‘mehd’ box giving
+// the duration of the movie. Each media segment holds one movie fragment and
+// the media data box following it.
+//
+// Fragments start at a sync sample of the reference track, which is the first
+// video track, or the first track if there is no video track, once the
+// preceding fragment lasts at least FragmentDuration. The other tracks are cut
+// at the same time, so that the fragments of all tracks are aligned; a
+// fragment of such a track starts at a sync sample only if the track consists
+// of sync samples, as audio tracks usually do.
+//
+// The sample groups and the sample auxiliary information of the samples in
+// the sample tables are moved into the track fragments, as ‘sbgp’ boxes and as
+// ‘senc’, ‘saiz’ and ‘saio’ boxes. The ‘sgpd’ boxes stay in the sample tables
+// of the initialization segment. Only the sample auxiliary information of
+// Common Encryption is supported.
+type Fragmenter struct {
+	// is written at the start of the initialization segment. NewFragmenter
+	// sets the brands ‘iso6’ and ‘dash’.
+	FileType *FileTypeBox
+
+	// the minimum duration of a fragment, which is two seconds unless set.
+	FragmentDuration time.Duration
+
+	moov      *MovieBox
+	r         io.ReaderAt
+	builder   *FragmentBuilder
+	tracks    []*fragmenterTrack
+	reference *fragmenterTrack
+}
+
+type fragmenterTrack struct {
+	trackID   uint32
+	timescale uint32
+	samples   *TrackSampleIterator
+
+	// the sample groupings and the sample auxiliary information of the
+	// sample table of the track.
+	groups  []fragmenterGroup
+	auxInfo *fragmenterAuxiliaryInformation
+
+	// the next sample of the track, if pending is set.
+	next    Sample
+	pending bool
+}
+
+// NewFragmenter returns a fragmenter of movie, which must have been read from
+// r. The samples are read through the sample iterators of the tracks, and
+// their data is read from r one fragment at a time. The movie box of the
+// movie is left unchanged.
+func NewFragmenter(movie *Movie, r io.ReaderAt) (f *Fragmenter, err error) {
+	f = &Fragmenter{
+		FileType:         newFragmentedFileType(),
+		FragmentDuration: 2 * time.Second,
+		r:                r,
+	}
+	if f.moov, err = newFragmentedMovieBox(movie.Moov); err != nil {
+		return
+	}
+	f.builder = NewFragmentBuilder(f.moov)
+	for _, track := range movie.Tracks {
+		t := &fragmenterTrack{trackID: track.TrackID, timescale: track.Timescale}
+		if t.timescale == 0 {
+			err = fmt.Errorf("track %d has no timescale: %w", track.TrackID, ErrInvalidFormat)
+			return
+		}
+		if t.samples, err = track.Samples(); err != nil {
+			return
+		}
+		if stbl := track.Trak.SampleTable(); stbl != nil {
+			for _, box := range stbl.Mp4BoxFindAll(SbgpBoxType) {
+				if sbgp, ok := box.(*SampleToGroupBox); ok {
+					t.groups = append(t.groups, fragmenterGroup{sbgp: sbgp, first: 1})
+				}
+			}
+			if t.auxInfo, err = newFragmenterAuxiliaryInformation(track, stbl); err != nil {
+				return
+			}
+		}
+		t.next, t.pending = t.samples.Next()
+		if err = t.samples.Err(); err != nil {
+			return
+		}
+		f.tracks = append(f.tracks, t)
+		if t.pending && track.HandlerType == VideFourCC && f.reference == nil {
+			f.reference = t
+		}
+	}
+	if f.reference == nil {
+		for _, t := range f.tracks {
+			if t.pending {
+				f.reference = t
+				break
+			}
+		}
+	}
+	return
+}
+
+// newFragmentedMovieBox returns a copy of moov for the initialization segment
+// of a fragmented file. The sample tables keep only their sample descriptions
+// and sample group descriptions, as all other information about the samples
+// is in the track fragments.
+func newFragmentedMovieBox(moov *MovieBox) (init *MovieBox, err error) {
+	if init, err = cloneMovieBox(moov); err != nil {
+		return
+	}
+	removeChildren(&init.Container, MvexBoxType, func(Box) bool { return true })
+
+	mvhd := init.MovieHeader()
+	if mvhd == nil {
+		err = fmt.Errorf("moov box has no mvhd box: %w", ErrInvalidFormat)
+		return
+	}
+	mehd := &MovieExtendsHeaderBox{FragmentDuration: mvhd.Duration}
+	if mehd.FragmentDuration > 0xFFFFFFFF {
+		mehd.Version = 1
+	}
+	mvhd.Duration = 0
+	mvex := &MovieExtendsBox{}
+	mvex.Mp4BoxAppend(mehd)
+	for _, trak := range init.Tracks() {
+		tkhd, mdhd, stbl := trak.TrackHeader(), trak.MediaHeader(), trak.SampleTable()
+		if tkhd == nil || mdhd == nil || stbl == nil {
+			err = fmt.Errorf("trak box lacks tkhd, mdhd or stbl box: %w", ErrInvalidFormat)
+			return
+		}
+		tkhd.Duration = 0
+		mdhd.Duration = 0
+		var children []Box
+		for _, child := range stbl.Mp4BoxChildren() {
+			if child.Mp4BoxType() == StsdBoxType || child.Mp4BoxType() == SgpdBoxType {
+				children = append(children, child)
+			}
+		}
+		stbl.Mp4BoxReplaceChildren(children)
+		(&SampleTableBuilder{}).Build(stbl)
+		mvex.Mp4BoxAppend(&TrackExtendsBox{TrackID: tkhd.TrackID, DefaultSampleDescrptionIndex: 1})
+	}
+	init.Mp4BoxAppend(mvex)
+	return
+}
+
+// newFragmentedFileType returns a file type box with the brands ‘iso6’ and
+// ‘dash’.
+func newFragmentedFileType() *FileTypeBox {
+	return &FileTypeBox{
+		MajorBrand:       Iso6FourCC,
+		CompatibleBrands: []FourCC{Iso6FourCC, DashFourCC},
+	}
+}
+
+// WriteInit writes the initialization segment, i.e. the file type box and the
+// movie box.
+func (f *Fragmenter) WriteInit(w io.Writer) (err error) {
+	f.FileType.Mp4BoxUpdate()
+	if err = f.FileType.Mp4BoxWrite(w); err != nil {
+		return
+	}
+	f.moov.Mp4BoxUpdate()
+	return f.moov.Mp4BoxWrite(w)
+}
+
+// WriteSegment writes the next media segment, i.e. a movie fragment box and
+// its media data box. It returns io.EOF once all samples are written.
+func (f *Fragmenter) WriteSegment(w io.Writer) (err error) {
+	// the fragment ends at the decode time of the sync sample of the
+	// reference track that starts the next fragment, or holds all remaining
+	// samples if there is none.
+	var (
+		end   time.Duration
+		last  = true
+		count int
+	)
+	if ref := f.reference; ref != nil && ref.pending {
+		start := ref.next.DecodeTime
+		for ref.pending {
+			if count > 0 && ref.next.IsSync && scaleDuration(ref.next.DecodeTime-start, ref.timescale) >= f.FragmentDuration {
+				end, last = scaleDuration(ref.next.DecodeTime, ref.timescale), false
+				break
+			}
+			if err = f.addSample(ref, count == 0); err != nil {
+				return
+			}
+			count++
+		}
+	}
+	for _, track := range f.tracks {
+		if track == f.reference {
+			continue
+		}
+		for n := 0; track.pending && (last || scaleDuration(track.next.DecodeTime, track.timescale) < end); n++ {
+			if err = f.addSample(track, n == 0); err != nil {
+				return
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		return io.EOF
+	}
+	return f.builder.WriteFragment(w)
+}
+
+// addSample adds the next sample of the track to the fragment builder, reading
+// its data and its sample auxiliary information. The decode time of the track
+// is set from the first sample of the fragment.
+func (f *Fragmenter) addSample(track *fragmenterTrack, first bool) (err error) {
+	sample := track.next
+	if first {
+		if err = f.builder.SetDecodeTime(track.trackID, sample.DecodeTime); err != nil {
+			return
+		}
+	}
+	media := MediaSample{
+		Data:                   make([]byte, sample.Size),
+		Duration:               sample.Duration,
+		CompositionOffset:      sample.CompositionOffset,
+		Flags:                  SampleFlags{SampleIsNonSyncSample: !sample.IsSync},
+		SampleDescriptionIndex: sample.SampleDescriptionIndex,
+	}
+	if _, err = io.ReadFull(io.NewSectionReader(f.r, int64(sample.Offset), int64(sample.Size)), media.Data); err != nil {
+		return
+	}
+	for i := range track.groups {
+		if group, ok := track.groups[i].group(sample.Number); ok {
+			media.Groups = append(media.Groups, group)
+		}
+	}
+	if track.auxInfo != nil {
+		if media.Encryption, err = track.auxInfo.read(f.r, &sample); err != nil {
+			return
+		}
+	}
+	if err = f.builder.AddSample(track.trackID, media); err != nil {
+		return
+	}
+	track.next, track.pending = track.samples.Next()
+	return track.samples.Err()
+}
+
+// fragmenterGroup walks the entries of a sample to group box of a sample
+// table.
+type fragmenterGroup struct {
+	sbgp  *SampleToGroupBox
+	entry int
+
+	// is the number of the first sample of the entry.
+	first uint32
+}
+
+// group returns the group of the sample with the given number, which must not
+// be less than that of the previous call. ok is false if the sample is in no
+// group of the grouping.
+func (g *fragmenterGroup) group(number uint32) (group MediaSampleGroup, ok bool) {
+	for g.entry < len(g.sbgp.Entries) && number >= g.first+g.sbgp.Entries[g.entry].SampleCount {
+		g.first += g.sbgp.Entries[g.entry].SampleCount
+		g.entry++
+	}
+	if g.entry == len(g.sbgp.Entries) || g.sbgp.Entries[g.entry].GroupDescriptionIndex == 0 {
+		return
+	}
+	group = MediaSampleGroup{
+		GroupingType:          g.sbgp.GroupingType,
+		GroupingTypeParameter: g.sbgp.GroupingTypeParameter,
+		GroupDescriptionIndex: g.sbgp.Entries[g.entry].GroupDescriptionIndex,
+	}
+	return group, true
+}
+
+// fragmenterAuxiliaryInformation reads the sample auxiliary information of the
+// samples of a sample table, which holds the initialization vectors and the
+// subsample maps of encrypted samples.
+type fragmenterAuxiliaryInformation struct {
+	trackID uint32
+	saiz    *SampleAuxiliaryInformationSizesBox
+	saio    *SampleAuxiliaryInformationOffsetsBox
+	stsc    *SampleToChunkBox
+	stsd    *SampleDescriptionBox
+
+	// the number of the next sample, and the offset of its auxiliary
+	// information.
+	number uint32
+	offset uint64
+
+	// the chunk of the previous sample, the ‘stsc’ entry of the chunk and the
+	// number of samples of the chunk that follow the previous sample.
+	chunk uint32
+	entry int
+	left  uint32
+}
+
+// newFragmenterAuxiliaryInformation returns a reader of the sample auxiliary
+// information of the sample table of the track, or nil if it has none. The
+// auxiliary information must be that of the protection scheme of the track.
+func newFragmenterAuxiliaryInformation(track *Track, stbl *SampleTableBox) (a *fragmenterAuxiliaryInformation, err error) {
+	saizBoxes, saioBoxes := stbl.Mp4BoxFindAll(SaizBoxType), stbl.Mp4BoxFindAll(SaioBoxType)
+	if len(saizBoxes) == 0 && len(saioBoxes) == 0 {
+		return
+	}
+	a = &fragmenterAuxiliaryInformation{trackID: track.TrackID, number: 1}
+	var ok1, ok2, ok3, ok4 bool
+	if len(saizBoxes) == 1 && len(saioBoxes) == 1 {
+		a.saiz, ok1 = saizBoxes[0].(*SampleAuxiliaryInformationSizesBox)
+		a.saio, ok2 = saioBoxes[0].(*SampleAuxiliaryInformationOffsetsBox)
+	}
+	a.stsc, ok3 = stbl.Mp4BoxFindFirst(StscBoxType).(*SampleToChunkBox)
+	a.stsd, ok4 = stbl.Mp4BoxFindFirst(StsdBoxType).(*SampleDescriptionBox)
+	if !ok1 || !ok2 || !ok3 || !ok4 || len(a.saio.Offsets) == 0 {
+		err = fmt.Errorf("track %d must have one saiz and one saio box: %w", track.TrackID, ErrInvalidFormat)
+		return
+	}
+	var schm *SchemeTypeBox
+	if track.SampleEntry != nil {
+		schm, _ = track.SampleEntry.Mp4BoxRecursiveFindFirst(SchmBoxType).(*SchemeTypeBox)
+	}
+	if schm == nil || a.saiz.Mp4BoxFlags()&FLAG_SAIZ_AUX_INFO_TYPE > 0 && a.saiz.AuxInfoType != schm.SchemeType {
+		err = fmt.Errorf("track %d has sample auxiliary information of an unsupported type: %w", track.TrackID, ErrInvalidFormat)
+		return
+	}
+	a.offset = a.saio.Offsets[0]
+	return
+}
+
+// read returns the encryption parameters of the sample, which must be the next
+// sample of the track, or nil if the sample is not encrypted.
+func (a *fragmenterAuxiliaryInformation) read(r io.ReaderAt, sample *Sample) (encryption *SampleEncryptionSampleEntry, err error) {
+	if sample.Number != a.number || sample.Number > a.saiz.NumSamples() {
+		return
+	}
+	a.number++
+
+	// with an offset for each chunk, the auxiliary information of the samples
+	// of a chunk is contiguous.
+	if len(a.saio.Offsets) > 1 {
+		if a.left == 0 {
+			a.chunk++
+			for a.entry+1 < len(a.stsc.Entries) && a.stsc.Entries[a.entry+1].FirstChunk <= a.chunk {
+				a.entry++
+			}
+			if a.entry >= len(a.stsc.Entries) || a.chunk > uint32(len(a.saio.Offsets)) {
+				err = fmt.Errorf("track %d has no saio offset for chunk %d: %w", a.trackID, a.chunk, ErrInvalidFormat)
+				return
+			}
+			a.left = a.stsc.Entries[a.entry].SamplesPerChunk
+			a.offset = a.saio.Offsets[a.chunk-1]
+		}
+		a.left--
+	}
+	size := a.saiz.DefaultSampleInfoSize
+	if size == 0 {
+		size = a.saiz.SampleInfoSizes[sample.Number-1]
+	}
+	if size == 0 {
+		return
+	}
+	data := make([]byte, size)
+	if _, err = r.ReadAt(data, int64(a.offset)); err != nil {
+		return
+	}
+	a.offset += uint64(size)
+
+	// the size of the initialization vector is given by the track encryption
+	// box of the sample entry, and the subsample map follows it if the
+	// auxiliary information is longer.
+	var ivSize int
+	if entries := a.stsd.Mp4BoxChildren(); sample.SampleDescriptionIndex >= 1 && int(sample.SampleDescriptionIndex) <= len(entries) {
+		if tenc, ok := entries[sample.SampleDescriptionIndex-1].Mp4BoxRecursiveFindFirst(TencBoxType).(*TrackEncryptionBox); ok {
+			ivSize = int(tenc.DefaultPerSampleIVSize)
+		}
+	}
+	if len(data) < ivSize || len(data) > ivSize && (len(data)-ivSize < 2 || len(data)-ivSize-2 != 6*int(binary.BigEndian.Uint16(data[ivSize:]))) {
+		err = fmt.Errorf("track %d has invalid sample auxiliary information for sample %d: %w", a.trackID, sample.Number, ErrInvalidFormat)
+		return
+	}
+	encryption = &SampleEncryptionSampleEntry{InitializationVector: data[:ivSize]}
+	for p := ivSize + 2; p < len(data); p += 6 {
+		encryption.Subsamples = append(encryption.Subsamples, SampleEncryptionSubsampleEntry{
+			BytesOfClearData:     binary.BigEndian.Uint16(data[p:]),
+			BytesOfProtectedData: binary.BigEndian.Uint32(data[p+2:]),
+		})
+	}
+	return
+}
+
+// WriteFile writes the initialization segment followed by all media segments
+// as a single file.
+func (f *Fragmenter) WriteFile(w io.Writer) (err error) {
+	if err = f.WriteInit(w); err != nil {
+		return
+	}
+	for {
+		if err = f.WriteSegment(w); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package mp4
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// newTestEncryptedMovie returns the movie box of the initialization segment of
+// a fragmented movie with a single encrypted video track.
+func newTestEncryptedMovie() *MovieBox {
+	schi := &SchemeInformationBox{}
+	schi.Mp4BoxAppend(&TrackEncryptionBox{DefaultIsProtected: 1, DefaultPerSampleIVSize: 8})
+	sinf := &ProtectionSchemeInfoBox{}
+	sinf.Mp4BoxAppend(&OriginalFormatBox{DataFormat: FourCC(Avc1BoxType)})
+	sinf.Mp4BoxAppend(&SchemeTypeBox{SchemeType: CencFourCC, SchemeVersion: 0x10000})
+	sinf.Mp4BoxAppend(schi)
+	encv := &VisualSampleEntryBox{}
+	encv.Type = EncvBoxType
+	encv.Mp4BoxAppend(sinf)
+
+	stsd := &SampleDescriptionBox{}
+	stsd.Mp4BoxAppend(encv)
+	stbl := &SampleTableBox{}
+	stbl.Mp4BoxAppend(stsd)
+	for _, box := range (&SampleTableBuilder{}).Boxes() {
+		stbl.Mp4BoxAppend(box)
+	}
+	minf := &MediaInformationBox{}
+	minf.Mp4BoxAppend(stbl)
+	mdia := &MediaBox{}
+	mdia.Mp4BoxAppend(&MediaHeaderBox{Timescale: 1000})
+	mdia.Mp4BoxAppend(&HandlerBox{HandlerType: VideFourCC})
+	mdia.Mp4BoxAppend(minf)
+	trak := &TrackBox{}
+	trak.Mp4BoxAppend(&TrackHeaderBox{TrackID: 1})
+	trak.Mp4BoxAppend(mdia)
+
+	mvex := &MovieExtendsBox{}
+	mvex.Mp4BoxAppend(&TrackExtendsBox{TrackID: 1, DefaultSampleDescrptionIndex: 1})
+	moov := &MovieBox{}
+	moov.Mp4BoxAppend(&MovieHeaderBox{Timescale: 1000, NextTrackID: 2})
+	moov.Mp4BoxAppend(trak)
+	moov.Mp4BoxAppend(mvex)
+	return moov
+}
+
+func TestFragmenterClearLead(t *testing.T) {
+	// one second of clear samples is followed by two seconds of encrypted
+	// samples, in segments of one second.
+	moov := newTestEncryptedMovie()
+	var init bytes.Buffer
+	moov.Mp4BoxUpdate()
+	if err := moov.Mp4BoxWrite(&init); err != nil {
+		t.Fatal(err)
+	}
+	b := NewFragmentBuilder(moov)
+	var (
+		segments []io.ReadSeeker
+		samples  []MediaSample
+	)
+	for n := 0; n < 3; n++ {
+		for i := 0; i < 25; i++ {
+			sample := MediaSample{
+				Data:     []byte{byte(n), byte(i), 'v', 'v', 'v', 'v'},
+				Duration: 40,
+				Flags:    SampleFlags{SampleIsNonSyncSample: i > 0},
+			}
+			if n > 0 {
+				sample.Encryption = &SampleEncryptionSampleEntry{
+					InitializationVector: []byte{1, 2, 3, 4, 5, 6, byte(n), byte(i)},
+					Subsamples:           []SampleEncryptionSubsampleEntry{{BytesOfClearData: 2, BytesOfProtectedData: 4}},
+				}
+			}
+			if err := b.AddSample(1, sample); err != nil {
+				t.Fatal(err)
+			}
+			samples = append(samples, sample)
+		}
+		var segment bytes.Buffer
+		if err := b.WriteFragment(&segment); err != nil {
+			t.Fatal(err)
+		}
+		segments = append(segments, bytes.NewReader(segment.Bytes()))
+	}
+	var progressive bytes.Buffer
+	if err := Defragment(&progressive, bytes.NewReader(init.Bytes()), segments...); err != nil {
+		t.Fatal(err)
+	}
+
+	// the fragments of two seconds written by the fragmenter start with the
+	// clear samples and end with encrypted samples.
+	movie, err := ReadMovie(bytes.NewReader(progressive.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFragmenter(movie, bytes.NewReader(progressive.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fragmented bytes.Buffer
+	if err = f.WriteFile(&fragmented); err != nil {
+		t.Fatal(err)
+	}
+	file := fragmented.Bytes()
+	if movie, err = ReadMovie(bytes.NewReader(file)); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	for _, fragment := range movie.Fragments {
+		trafs, err := resolveTrackFragments(movie.Moov, fragment.Moof, fragment.Offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, traf := range fragment.Moof.TrackFragments() {
+			senc, _ := traf.Mp4BoxFindFirst(SencBoxType).(*SampleEncryptionBox)
+			if senc != nil && len(senc.Samples) != len(trafs[i]) {
+				t.Fatalf("senc box has %d of %d samples", len(senc.Samples), len(trafs[i]))
+			}
+			for j, sample := range trafs[i] {
+				if n >= len(samples) {
+					t.Fatal("movie has too many samples")
+				}
+				want := samples[n]
+				if got := file[sample.Offset : sample.Offset+uint64(sample.Size)]; !bytes.Equal(got, want.Data) {
+					t.Errorf("sample %d is %x, want %x", n, got, want.Data)
+				}
+				switch {
+				case want.Encryption == nil && senc != nil:
+					t.Errorf("clear sample %d is in a track fragment with a senc box", n)
+				case want.Encryption != nil && senc == nil:
+					t.Errorf("encrypted sample %d is in a track fragment without a senc box", n)
+				case want.Encryption != nil && !bytes.Equal(senc.Samples[j].InitializationVector, want.Encryption.InitializationVector):
+					t.Errorf("sample %d has initialization vector %x, want %x", n, senc.Samples[j].InitializationVector, want.Encryption.InitializationVector)
+				}
+				n++
+			}
+		}
+	}
+	if n != len(samples) {
+		t.Errorf("movie has %d samples, want %d", n, len(samples))
+	}
+}
@@ -0,0 +1,401 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+)
+
+// Keys of QuickTime metadata in the ‘mdta’ namespace.
+const (
+	QuickTimeKeyTitle        = "com.apple.quicktime.title"
+	QuickTimeKeyArtist       = "com.apple.quicktime.artist"
+	QuickTimeKeyAuthor       = "com.apple.quicktime.author"
+	QuickTimeKeyComment      = "com.apple.quicktime.comment"
+	QuickTimeKeyCopyright    = "com.apple.quicktime.copyright"
+	QuickTimeKeyCreationDate = "com.apple.quicktime.creationdate"
+	QuickTimeKeyDescription  = "com.apple.quicktime.description"
+	QuickTimeKeyGenre        = "com.apple.quicktime.genre"
+	QuickTimeKeyLocation     = "com.apple.quicktime.location.ISO6709"
+	QuickTimeKeyMake         = "com.apple.quicktime.make"
+	QuickTimeKeyModel        = "com.apple.quicktime.model"
+	QuickTimeKeySoftware     = "com.apple.quicktime.software"
+)
+
+// Domain of the freeform items written by iTunes.
+const ITunesFreeformMean = "com.apple.iTunes"
+
+// NewITunesMetaBox returns a meta box for iTunes‐style metadata, with an
+// ‘mdir’ handler and an empty item list. It belongs in the user data box of
+// the movie box.
+func NewITunesMetaBox() *MetaBox {
+	meta := &MetaBox{}
+	meta.Mp4BoxAppend(&HandlerBox{HandlerType: MdirFourCC})
+	meta.Mp4BoxAppend(&ItemListBox{})
+	return meta
+}
+
+// NewQuickTimeMetaBox returns a QuickTime meta box for metadata with reverse
+// DNS keys, with an ‘mdta’ handler, an empty keys box and an empty item list.
+// It belongs in the movie box or a track box.
+func NewQuickTimeMetaBox() *MetaBox {
+	meta := &MetaBox{QuickTime: true}
+	meta.Mp4BoxAppend(&HandlerBox{HandlerType: MdtaFourCC})
+	meta.Mp4BoxAppend(&KeysBox{})
+	meta.Mp4BoxAppend(&ItemListBox{})
+	return meta
+}
+
+// Item returns the first item of the given type, or nil if there is none.
+func (b *ItemListBox) Item(itemType BoxType) *MetadataItemBox {
+	item, _ := b.Mp4BoxFindFirst(itemType).(*MetadataItemBox)
+	return item
+}
+
+// Data returns the first value of the item of the given type, or nil if
+// there is none.
+func (b *ItemListBox) Data(itemType BoxType) *DataBox {
+	if item := b.Item(itemType); item != nil {
+		if data := item.Data(); len(data) > 0 {
+			return data[0]
+		}
+	}
+	return nil
+}
+
+// SetData replaces the values of the item of the given type, adding the item
+// if needed. The item is removed if no data is given.
+func (b *ItemListBox) SetData(itemType BoxType, data ...*DataBox) {
+	if len(data) == 0 {
+		b.Remove(itemType)
+		return
+	}
+	children := make([]Box, len(data))
+	for i, d := range data {
+		children[i] = d
+	}
+	item := b.Item(itemType)
+	if item == nil {
+		item = &MetadataItemBox{Header: Header{Type: itemType}}
+		b.Mp4BoxAppend(item)
+	}
+	item.Mp4BoxReplaceChildren(children)
+}
+
+// Remove removes the items of the given type.
+func (b *ItemListBox) Remove(itemType BoxType) {
+	removeChildren(&b.Container, itemType, func(Box) bool { return true })
+}
+
+// Text returns the text value of the item of the given type, e.g. of
+// MetadataItemTitle.
+func (b *ItemListBox) Text(itemType BoxType) (value string, ok bool) {
+	if data := b.Data(itemType); data != nil {
+		return data.Text(), true
+	}
+	return
+}
+
+// SetText sets the item of the given type to a UTF‐8 text value.
+func (b *ItemListBox) SetText(itemType BoxType, value string) {
+	b.SetData(itemType, NewStringDataBox(value))
+}
+
+// Integer returns the integer value of the item of the given type, e.g. of
+// MetadataItemTVEpisode.
+func (b *ItemListBox) Integer(itemType BoxType) (value int64, ok bool) {
+	if data := b.Data(itemType); data != nil {
+		return data.Integer()
+	}
+	return
+}
+
+// SetInteger sets the item of the given type to an integer value. The value
+// is stored in the size iTunes uses for the item type, 4 bytes for unknown
+// types, or 8 bytes if it does not fit in 4 bytes.
+func (b *ItemListBox) SetInteger(itemType BoxType, value int64) {
+	size := 4
+	switch itemType {
+	case MetadataItemMediaKind, BoxType{'r', 't', 'n', 'g'}, BoxType{'p', 'g', 'a', 'p'},
+		BoxType{'c', 'p', 'i', 'l'}, BoxType{'h', 'd', 'v', 'd'}, BoxType{'p', 'c', 's', 't'}:
+		size = 1
+	case BoxType{'t', 'm', 'p', 'o'}:
+		size = 2
+	default:
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			size = 8
+		}
+	}
+	b.SetData(itemType, NewIntegerDataBox(value, size))
+}
+
+// IndexPair returns the index and total of an item such as
+// MetadataItemTrackNumber or MetadataItemDiskNumber.
+func (b *ItemListBox) IndexPair(itemType BoxType) (index, total uint16, ok bool) {
+	data := b.Data(itemType)
+	if data == nil || len(data.Value) < 6 {
+		return
+	}
+	return binary.BigEndian.Uint16(data.Value[2:]), binary.BigEndian.Uint16(data.Value[4:]), true
+}
+
+// SetIndexPair sets the index and total of an item such as
+// MetadataItemTrackNumber or MetadataItemDiskNumber.
+func (b *ItemListBox) SetIndexPair(itemType BoxType, index, total uint16) {
+	value := make([]byte, 8)
+	if itemType == MetadataItemDiskNumber {
+		value = value[:6]
+	}
+	binary.BigEndian.PutUint16(value[2:], index)
+	binary.BigEndian.PutUint16(value[4:], total)
+	b.SetData(itemType, &DataBox{DataType: MetadataDataTypeBinary, Value: value})
+}
+
+// CoverArt returns the images of the cover art item. The DataType of each
+// tells the image format.
+func (b *ItemListBox) CoverArt() []*DataBox {
+	if item := b.Item(MetadataItemCoverArt); item != nil {
+		return item.Data()
+	}
+	return nil
+}
+
+// AddCoverArt adds an image of the given format, e.g. MetadataDataTypeJPEG,
+// to the cover art item.
+func (b *ItemListBox) AddCoverArt(dataType MetadataDataType, image []byte) {
+	b.SetData(MetadataItemCoverArt, append(b.CoverArt(), &DataBox{DataType: dataType, Value: image})...)
+}
+
+// Freeform returns the freeform item with the given domain and name, or nil
+// if there is none.
+func (b *ItemListBox) Freeform(mean, name string) *MetadataItemBox {
+	for _, box := range b.Mp4BoxFindAll(MetadataItemFreeform) {
+		if item, ok := box.(*MetadataItemBox); ok && item.Mean() == mean && item.Name() == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// FreeformText returns the text value of the freeform item with the given
+// domain and name.
+func (b *ItemListBox) FreeformText(mean, name string) (value string, ok bool) {
+	if item := b.Freeform(mean, name); item != nil {
+		if data := item.Data(); len(data) > 0 {
+			return data[0].Text(), true
+		}
+	}
+	return
+}
+
+// SetFreeformText sets the freeform item with the given domain and name to a
+// UTF‐8 text value, adding the item if needed.
+func (b *ItemListBox) SetFreeformText(mean, name, value string) {
+	item := b.Freeform(mean, name)
+	if item == nil {
+		item = &MetadataItemBox{Header: Header{Type: MetadataItemFreeform}}
+		b.Mp4BoxAppend(item)
+	}
+	item.Mp4BoxReplaceChildren([]Box{&MeanBox{Mean: mean}, &NameBox{Name: name}, NewStringDataBox(value)})
+}
+
+// RemoveFreeform removes the freeform item with the given domain and name.
+func (b *ItemListBox) RemoveFreeform(mean, name string) {
+	removeChildren(&b.Container, MetadataItemFreeform, func(box Box) bool {
+		item, ok := box.(*MetadataItemBox)
+		return ok && item.Mean() == mean && item.Name() == name
+	})
+}
+
+// KeyedItem returns the item of the QuickTime metadata key, or nil if there
+// is none.
+func (b *MetaBox) KeyedItem(key string) *MetadataItemBox {
+	keys, ilst := b.Keys(), b.ItemList()
+	if keys == nil || ilst == nil {
+		return nil
+	}
+	index := keys.Index(key)
+	if index == 0 {
+		return nil
+	}
+	return ilst.Item(keyIndexBoxType(index))
+}
+
+// KeyedText returns the text value of the QuickTime metadata key, e.g. of
+// QuickTimeKeyTitle.
+func (b *MetaBox) KeyedText(key string) (value string, ok bool) {
+	if item := b.KeyedItem(key); item != nil {
+		if data := item.Data(); len(data) > 0 {
+			return data[0].Text(), true
+		}
+	}
+	return
+}
+
+// SetKeyedData replaces the values of the QuickTime metadata key. The key and
+// the keys and item list boxes are added if needed.
+func (b *MetaBox) SetKeyedData(key string, data ...*DataBox) {
+	keys := b.Keys()
+	if keys == nil {
+		keys = &KeysBox{}
+		b.Mp4BoxAppend(keys)
+	}
+	ilst := b.ItemList()
+	if ilst == nil {
+		ilst = &ItemListBox{}
+		b.Mp4BoxAppend(ilst)
+	}
+	index := keys.Index(key)
+	if index == 0 {
+		keys.Entries = append(keys.Entries, MetadataKey{Namespace: MdtaFourCC, Value: key})
+		index = uint32(len(keys.Entries))
+	}
+	ilst.SetData(keyIndexBoxType(index), data...)
+}
+
+// SetKeyedText sets the QuickTime metadata key to a UTF‐8 text value.
+func (b *MetaBox) SetKeyedText(key, value string) {
+	b.SetKeyedData(key, NewStringDataBox(value))
+}
+
+func keyIndexBoxType(index uint32) (boxType BoxType) {
+	binary.BigEndian.PutUint32(boxType[:], index)
+	return
+}
+
+// Metadata is a unified view of the descriptive metadata of a movie or a
+// track. It is merged from iTunes‐style items, QuickTime keyed metadata and
+// 3GPP asset information, in that order of precedence. For tracks, the
+// handler name serves as the title if no other source has one, unless it is
+// one of the generic names written by common muxers.
+type Metadata struct {
+	Title       string
+	Artist      string
+	Description string
+	Copyright   string
+	Genre       string
+
+	// is 0 if unknown.
+	Year int
+}
+
+var genericHandlerNames = map[string]bool{
+	"VideoHandler":              true,
+	"SoundHandler":              true,
+	"SubtitleHandler":           true,
+	"TextHandler":               true,
+	"Core Media Video":          true,
+	"Core Media Audio":          true,
+	"Core Media Text":           true,
+	"Core Media Closed Caption": true,
+	"Core Media Metadata":       true,
+	"Apple Video Media Handler": true,
+	"Apple Sound Media Handler": true,
+	"Apple Text Media Handler":  true,
+	"GPAC ISO Video Handler":    true,
+	"GPAC ISO Audio Handler":    true,
+	"GPAC ISO Subtitle Handler": true,
+	"Bento4 Video Handler":      true,
+	"Bento4 Sound Handler":      true,
+	"L-SMASH Video Handler":     true,
+	"L-SMASH Audio Handler":     true,
+}
+
+// Metadata returns the unified metadata of the movie.
+func (b *MovieBox) Metadata() Metadata {
+	return readMetadata(&b.Container)
+}
+
+// Metadata returns the unified metadata of the track.
+func (b *TrackBox) Metadata() (m Metadata) {
+	m = readMetadata(&b.Container)
+	if m.Title != "" {
+		return
+	}
+	if hdlr := b.Handler(); hdlr != nil && !genericHandlerNames[string(hdlr.Name)] {
+		m.Title = string(hdlr.Name)
+	}
+	return
+}
+
+// readMetadata merges the metadata of the meta boxes and 3GPP asset boxes of
+// a movie or track box and its user data box.
+func readMetadata(b *Container) (m Metadata) {
+	set := func(field *string) func(string, bool) {
+		return func(value string, ok bool) {
+			if *field == "" && ok {
+				*field = value
+			}
+		}
+	}
+	setYear := func(value string, ok bool) {
+		if m.Year == 0 && ok {
+			m.Year = parseYear(value)
+		}
+	}
+	udta, _ := b.Mp4BoxFindFirst(UdtaBoxType).(*UserDataBox)
+	metas := b.Mp4BoxFindAll(MetaBoxType)
+	if udta != nil {
+		metas = append(udta.Mp4BoxFindAll(MetaBoxType), metas...)
+	}
+	for _, box := range metas {
+		meta, ok := box.(*MetaBox)
+		if !ok || meta.Keys() != nil {
+			continue
+		}
+		if ilst := meta.ItemList(); ilst != nil {
+			set(&m.Title)(ilst.Text(MetadataItemTitle))
+			set(&m.Artist)(ilst.Text(MetadataItemArtist))
+			set(&m.Artist)(ilst.Text(MetadataItemAlbumArtist))
+			set(&m.Description)(ilst.Text(MetadataItemDescription))
+			set(&m.Description)(ilst.Text(MetadataItemLongDescription))
+			set(&m.Copyright)(ilst.Text(MetadataItemCopyright))
+			set(&m.Genre)(ilst.Text(MetadataItemGenre))
+			setYear(ilst.Text(MetadataItemDate))
+		}
+	}
+	for _, box := range metas {
+		if meta, ok := box.(*MetaBox); ok && meta.Keys() != nil {
+			set(&m.Title)(meta.KeyedText(QuickTimeKeyTitle))
+			set(&m.Artist)(meta.KeyedText(QuickTimeKeyArtist))
+			set(&m.Artist)(meta.KeyedText(QuickTimeKeyAuthor))
+			set(&m.Description)(meta.KeyedText(QuickTimeKeyDescription))
+			set(&m.Copyright)(meta.KeyedText(QuickTimeKeyCopyright))
+			set(&m.Genre)(meta.KeyedText(QuickTimeKeyGenre))
+			setYear(meta.KeyedText(QuickTimeKeyCreationDate))
+		}
+	}
+	if udta == nil {
+		return
+	}
+	asset := func(boxType BoxType) (value string, ok bool) {
+		var box *AssetInformationBox
+		if box, ok = udta.Mp4BoxFindFirst(boxType).(*AssetInformationBox); ok {
+			value = box.Value
+		}
+		return
+	}
+	set(&m.Title)(asset(TitlBoxType))
+	set(&m.Artist)(asset(PerfBoxType))
+	set(&m.Artist)(asset(AuthBoxType))
+	set(&m.Description)(asset(DscpBoxType))
+	set(&m.Copyright)(asset(CprtBoxType))
+	set(&m.Genre)(asset(GnreBoxType))
+	if yrrc, ok := udta.Mp4BoxFindFirst(YrrcBoxType).(*RecordingYearBox); ok && m.Year == 0 {
+		m.Year = int(yrrc.RecordingYear)
+	}
+	return
+}
+
+// parseYear returns the year of a date starting with four digits, such as
+// ‘2006’ or ‘2006‐05‐01T12:00:00Z’, or 0.
+func parseYear(date string) int {
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil || year < 0 {
+		return 0
+	}
+	return year
+}
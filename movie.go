@@ -0,0 +1,459 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// Movie is the high‐level view of a file, whether progressive or fragmented.
+// It holds the movie box, the movie fragments following it and a Track for
+// each track of the movie box.
+type Movie struct {
+	Moov      *MovieBox
+	Fragments []*MovieFragment
+	Tracks    []*Track
+
+	// locates each top‐level box of the file, in file order.
+	Boxes []FileBox
+}
+
+// FileBox is a top‐level box of a file, located by the file position of its
+// first byte and its size. A Size of 0 means that the box extends to the end
+// of the file.
+type FileBox struct {
+	Type   BoxType
+	Offset uint64
+	Size   uint64
+}
+
+// MovieFragment is a movie fragment box with the file position of its first
+// byte.
+type MovieFragment struct {
+	Moof   *MovieFragmentBox
+	Offset uint64
+}
+
+// Track describes a track of a Movie.
+type Track struct {
+	Trak *TrackBox
+
+	TrackID     uint32
+	HandlerType FourCC
+
+	// is the format of the first sample entry, or the original format of an
+	// encrypted or restricted sample entry, e.g. ‘avc1’ for an ‘encv’ entry.
+	Codec FourCC
+
+	Language  language.Base
+	Timescale uint32
+
+	// is the duration of the media in units of Timescale. For fragmented
+	// files with no duration in the media header box, it is the end of the
+	// last sample of the track on the media timeline.
+	Duration uint64
+
+	// is the first child of the sample description box.
+	SampleEntry Box
+
+	movie *Movie
+}
+
+// ReadMovie reads the top‐level boxes of a file. Only the movie box and the
+// movie fragment boxes are parsed; the payload of all other boxes, such as
+// media data boxes, is skipped. Boxes with a 64‐bit size are supported for
+// skipped boxes only.
+func ReadMovie(r io.ReadSeeker) (movie *Movie, err error) {
+	var (
+		moov      *MovieBox
+		fragments []*MovieFragment
+		boxes     []FileBox
+	)
+	if moov, fragments, boxes, err = readTopLevelBoxes(r); err != nil {
+		return
+	}
+	if moov == nil {
+		err = fmt.Errorf("file has no moov box: %w", ErrInvalidFormat)
+		return
+	}
+	return newMovie(moov, fragments, boxes)
+}
+
+// readTopLevelBoxes reads the top‐level boxes of a file or segment as
+// described for ReadMovie. moov is nil if there is no movie box, as in a
+// media segment.
+func readTopLevelBoxes(r io.ReadSeeker) (moov *MovieBox, fragments []*MovieFragment, boxes []FileBox, err error) {
+	var offset uint64
+	for {
+		var header *Header
+		if header, err = ReadHeader(r); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+				break
+			}
+			return
+		}
+		// a size of 0 means that the box extends to the end of the file, and
+		// a size of 1 that the 64‐bit size follows the box type.
+		size := uint64(header.Size)
+		if header.Size == 1 {
+			if err = binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+		}
+		boxes = append(boxes, FileBox{Type: header.Type, Offset: offset, Size: size})
+		switch header.Type {
+		case MoovBoxType, MoofBoxType:
+			if header.Size < 8 {
+				err = fmt.Errorf("%s box has unsupported size %d: %w", header.Type, header.Size, ErrInvalidFormat)
+				return
+			}
+			var box Box
+			if box, err = ReadBoxAfterHeader(r, header); err != nil {
+				return
+			}
+			if moof, ok := box.(*MovieFragmentBox); ok {
+				fragments = append(fragments, &MovieFragment{Moof: moof, Offset: offset})
+			} else if moov == nil {
+				moov = box.(*MovieBox)
+			}
+		default:
+			if size == 0 {
+				break
+			}
+			headerSize := uint64(header.HeaderSize())
+			if header.Size == 1 {
+				headerSize += 8
+			}
+			if size < headerSize {
+				err = fmt.Errorf("%s box has invalid size %d: %w", header.Type, size, ErrInvalidFormat)
+				return
+			}
+			if _, err = r.Seek(int64(size-headerSize), io.SeekCurrent); err != nil {
+				return
+			}
+		}
+		if size == 0 {
+			break
+		}
+		offset += size
+	}
+	return
+}
+
+// NewMovie builds a Movie from the top‐level boxes of a file, in file order
+// starting at the beginning of the file. The file position of each movie
+// fragment box is derived from the sizes of the preceding boxes.
+func NewMovie(boxes []Box) (movie *Movie, err error) {
+	var (
+		moov      *MovieBox
+		fragments []*MovieFragment
+		fileBoxes []FileBox
+		offset    uint64
+	)
+	for _, box := range boxes {
+		switch box := box.(type) {
+		case *MovieBox:
+			if moov == nil {
+				moov = box
+			}
+		case *MovieFragmentBox:
+			fragments = append(fragments, &MovieFragment{Moof: box, Offset: offset})
+		}
+		fileBoxes = append(fileBoxes, FileBox{Type: box.Mp4BoxType(), Offset: offset, Size: uint64(box.Mp4BoxSize())})
+		offset += uint64(box.Mp4BoxSize())
+	}
+	if moov == nil {
+		err = fmt.Errorf("file has no moov box: %w", ErrInvalidFormat)
+		return
+	}
+	return newMovie(moov, fragments, fileBoxes)
+}
+
+func newMovie(moov *MovieBox, fragments []*MovieFragment, boxes []FileBox) (movie *Movie, err error) {
+	movie = &Movie{Moov: moov, Fragments: fragments, Boxes: boxes}
+	for _, trak := range moov.Tracks() {
+		tkhd := trak.TrackHeader()
+		mdhd := trak.MediaHeader()
+		hdlr := trak.Handler()
+		if tkhd == nil || mdhd == nil || hdlr == nil {
+			err = fmt.Errorf("trak box lacks tkhd, mdhd or hdlr box: %w", ErrInvalidFormat)
+			return
+		}
+		track := &Track{
+			Trak:        trak,
+			TrackID:     tkhd.TrackID,
+			HandlerType: hdlr.HandlerType,
+			Language:    mdhd.Language,
+			Timescale:   mdhd.Timescale,
+			Duration:    mdhd.Duration,
+			movie:       movie,
+		}
+		if stbl := trak.SampleTable(); stbl != nil {
+			if stsd, ok := stbl.Mp4BoxFindFirst(StsdBoxType).(*SampleDescriptionBox); ok {
+				track.SampleEntry = stsd.Mp4BoxFirstChild()
+			}
+		}
+		if track.SampleEntry != nil {
+			track.Codec = FourCC(track.SampleEntry.Mp4BoxType())
+			// the original format is in the ‘sinf’ box of a protected entry
+			// or in the ‘rinf’ box of a restricted entry.
+			for _, boxType := range []BoxType{SinfBoxType, RinfBoxType} {
+				if info := track.SampleEntry.Mp4BoxFindFirst(boxType); info != nil {
+					if frma, ok := info.Mp4BoxFindFirst(FrmaBoxType).(*OriginalFormatBox); ok {
+						track.Codec = frma.DataFormat
+						break
+					}
+				}
+			}
+		}
+		if track.Duration == 0 && movie.Fragmented() {
+			var it *TrackSampleIterator
+			if it, err = track.Samples(); err != nil {
+				return
+			}
+			track.Duration = it.Duration()
+		}
+		movie.Tracks = append(movie.Tracks, track)
+	}
+	return
+}
+
+// Fragmented reports whether the movie is fragmented, i.e. whether the movie
+// box has a movie extends box or movie fragments follow it.
+func (m *Movie) Fragmented() bool {
+	return len(m.Fragments) > 0 || m.Moov.Mp4BoxFindFirst(MvexBoxType) != nil
+}
+
+// Track returns the track with the given track ID, or nil if there is none.
+func (m *Movie) Track(trackID uint32) *Track {
+	for _, track := range m.Tracks {
+		if track.TrackID == trackID {
+			return track
+		}
+	}
+	return nil
+}
+
+// TrackSampleIterator walks the samples of a track of a Movie, first those of
+// the sample table of the track and then those of the movie fragments. Sample
+// numbers run on across fragments. Decode times of track fragments without a
+// ‘tfdt’ box continue from the end of the preceding samples of the track.
+type TrackSampleIterator struct {
+	trackID uint32
+	movie   *Movie
+
+	progressive      *SampleIterator
+	progressiveCount uint32
+	progressiveEnd   uint64
+
+	fragments []trackFragmentRange
+	count     uint32
+	number    uint32 // 1‐based number of the next sample
+
+	// holds the samples of the fragment with index loaded, or -1.
+	loaded  int
+	samples []Sample
+
+	// the error that made Next return false, if any.
+	err error
+}
+
+// trackFragmentRange is the range of the samples of a track in a movie
+// fragment.
+type trackFragmentRange struct {
+	fragment    *MovieFragment
+	firstNumber uint32
+	count       uint32
+	startTime   uint64
+	endTime     uint64
+}
+
+// Samples returns an iterator over the samples of the track. The movie
+// fragments are indexed once, which resolves their samples.
+func (t *Track) Samples() (it *TrackSampleIterator, err error) {
+	it = &TrackSampleIterator{trackID: t.TrackID, movie: t.movie, loaded: -1, number: 1}
+	// the sample table of a fragmented track is usually empty, and may then
+	// lack some of the boxes.
+	if stbl := t.Trak.SampleTable(); stbl != nil && !isEmptySampleTable(stbl) {
+		if it.progressive, err = NewSampleIterator(stbl); err != nil {
+			return
+		}
+		it.progressiveCount = it.progressive.SampleCount()
+		it.progressiveEnd = it.progressive.Duration()
+	}
+	it.count = it.progressiveCount
+	endTime := it.progressiveEnd
+	for _, fragment := range t.movie.Fragments {
+		var samples []Sample
+		if samples, endTime, err = it.resolveFragment(fragment, endTime); err != nil {
+			return
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		it.fragments = append(it.fragments, trackFragmentRange{
+			fragment:    fragment,
+			firstNumber: it.count + 1,
+			count:       uint32(len(samples)),
+			startTime:   samples[0].DecodeTime,
+			endTime:     endTime,
+		})
+		it.count += uint32(len(samples))
+	}
+	return
+}
+
+func isEmptySampleTable(stbl *SampleTableBox) bool {
+	stsz, ok := stbl.Mp4BoxFindFirst(StszBoxType).(*SampleSizeBox)
+	return !ok || stsz.NumSamples() == 0
+}
+
+// resolveFragment returns the samples of the track in the movie fragment,
+// numbered from 1. Track fragments without a ‘tfdt’ box start at startTime or
+// at the end of the preceding track fragment.
+func (it *TrackSampleIterator) resolveFragment(fragment *MovieFragment, startTime uint64) (samples []Sample, endTime uint64, err error) {
+	var trafs [][]FragmentSample
+	if trafs, err = resolveTrackFragments(it.movie.Moov, fragment.Moof, fragment.Offset); err != nil {
+		return
+	}
+	endTime = startTime
+	for i, traf := range fragment.Moof.TrackFragments() {
+		if tfhd := traf.TrackFragmentHeader(); tfhd == nil || tfhd.TrackID != it.trackID {
+			continue
+		}
+		var shift uint64
+		if _, ok := traf.BaseMediaDecodeTime(); !ok {
+			shift = endTime
+		}
+		for _, sample := range trafs[i] {
+			sample.DecodeTime += shift
+			sample.Number = uint32(len(samples)) + 1
+			samples = append(samples, sample.Sample)
+			endTime = sample.DecodeTime + uint64(sample.Duration)
+		}
+	}
+	return
+}
+
+// load makes the samples of the fragment with the given index available.
+func (it *TrackSampleIterator) load(index int) (err error) {
+	if it.loaded == index {
+		return
+	}
+	startTime := it.progressiveEnd
+	if index > 0 {
+		startTime = it.fragments[index-1].endTime
+	}
+	r := &it.fragments[index]
+	var samples []Sample
+	if samples, _, err = it.resolveFragment(r.fragment, startTime); err != nil {
+		return
+	}
+	for i := range samples {
+		samples[i].Number += r.firstNumber - 1
+	}
+	it.samples = samples
+	it.loaded = index
+	return
+}
+
+// SampleCount returns the number of samples of the track.
+func (it *TrackSampleIterator) SampleCount() uint32 {
+	return it.count
+}
+
+// Duration returns the end of the last sample of the track on the media
+// timeline.
+func (it *TrackSampleIterator) Duration() uint64 {
+	if len(it.fragments) > 0 {
+		return it.fragments[len(it.fragments)-1].endTime
+	}
+	return it.progressiveEnd
+}
+
+// fragmentIndex returns the index of the fragment holding the sample with the
+// given number, which must be a fragment sample.
+func (it *TrackSampleIterator) fragmentIndex(number uint32) int {
+	return sort.Search(len(it.fragments), func(i int) bool {
+		r := &it.fragments[i]
+		return r.firstNumber+r.count > number
+	})
+}
+
+// Next returns the next sample, or false once all samples are returned or the
+// samples of a movie fragment cannot be resolved, which Err then reports.
+func (it *TrackSampleIterator) Next() (sample Sample, ok bool) {
+	if it.number > it.count {
+		return
+	}
+	if it.number <= it.progressiveCount {
+		if sample, ok = it.progressive.Next(); ok {
+			it.number++
+		}
+		return
+	}
+	index := it.loaded
+	if index < 0 || it.number < it.fragments[index].firstNumber || it.number >= it.fragments[index].firstNumber+it.fragments[index].count {
+		index = it.fragmentIndex(it.number)
+	}
+	if it.err = it.load(index); it.err != nil {
+		return
+	}
+	sample = it.samples[it.number-it.fragments[index].firstNumber]
+	it.number++
+	ok = true
+	return
+}
+
+// Err returns the error that made Next return false, or nil if Next returned
+// false because all samples were returned.
+func (it *TrackSampleIterator) Err() error {
+	return it.err
+}
+
+// Seek positions the iterator so that Next returns the sample with the given
+// 1‐based number. Seeking to SampleCount()+1 positions the iterator at the
+// end.
+func (it *TrackSampleIterator) Seek(number uint32) (err error) {
+	if number == 0 || number > it.count+1 {
+		err = fmt.Errorf("sample number %d out of range 1..%d", number, it.count)
+		return
+	}
+	if number <= it.progressiveCount {
+		if err = it.progressive.Seek(number); err != nil {
+			return
+		}
+	}
+	it.number = number
+	it.err = nil
+	return
+}
+
+// SeekTime positions the iterator at the last sync sample whose decode time is
+// not after the given decode time, so that decoding can start there. If there
+// is no such sync sample, the iterator is positioned at the first sample.
+func (it *TrackSampleIterator) SeekTime(decodeTime uint64) (err error) {
+	for index := sort.Search(len(it.fragments), func(i int) bool {
+		return it.fragments[i].startTime > decodeTime
+	}) - 1; index >= 0; index-- {
+		if err = it.load(index); err != nil {
+			return
+		}
+		for i := len(it.samples) - 1; i >= 0; i-- {
+			if sample := &it.samples[i]; sample.IsSync && sample.DecodeTime <= decodeTime {
+				return it.Seek(sample.Number)
+			}
+		}
+	}
+	if it.progressiveCount > 0 {
+		if number := it.progressive.SyncSampleAtOrBefore(it.progressive.SampleNumberAt(decodeTime)); number > 0 {
+			return it.Seek(number)
+		}
+	}
+	return it.Seek(1)
+}
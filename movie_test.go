@@ -0,0 +1,70 @@
+package mp4
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadMovieCodec(t *testing.T) {
+	newEntry := func(boxType BoxType, info Box) Box {
+		entry := &VisualSampleEntryBox{}
+		entry.Type = boxType
+		if info != nil {
+			entry.Mp4BoxAppend(info)
+		}
+		return entry
+	}
+	sinf := &ProtectionSchemeInfoBox{}
+	sinf.Mp4BoxAppend(&OriginalFormatBox{DataFormat: FourCC(Avc1BoxType)})
+	rinf := &RestrictedSchemeInfoBox{}
+	rinf.Mp4BoxAppend(&OriginalFormatBox{DataFormat: FourCC(Hvc1BoxType)})
+	rinf.Mp4BoxAppend(&SchemeTypeBox{SchemeType: FourCC{'s', 't', 'v', 'i'}})
+
+	for _, test := range []struct {
+		name  string
+		entry Box
+		want  FourCC
+	}{
+		{"plain", newEntry(Vp09BoxType, nil), FourCC(Vp09BoxType)},
+		{"protected", newEntry(EncvBoxType, sinf), FourCC(Avc1BoxType)},
+		{"restricted", newEntry(ResvBoxType, rinf), FourCC(Hvc1BoxType)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stsd := &SampleDescriptionBox{}
+			stsd.Mp4BoxAppend(test.entry)
+			stbl := &SampleTableBox{}
+			stbl.Mp4BoxAppend(stsd)
+			for _, box := range (&SampleTableBuilder{}).Boxes() {
+				stbl.Mp4BoxAppend(box)
+			}
+			minf := &MediaInformationBox{}
+			minf.Mp4BoxAppend(stbl)
+			mdia := &MediaBox{}
+			mdia.Mp4BoxAppend(&MediaHeaderBox{Timescale: 1000})
+			mdia.Mp4BoxAppend(&HandlerBox{HandlerType: VideFourCC})
+			mdia.Mp4BoxAppend(minf)
+			trak := &TrackBox{}
+			trak.Mp4BoxAppend(&TrackHeaderBox{TrackID: 1})
+			trak.Mp4BoxAppend(mdia)
+			moov := &MovieBox{}
+			moov.Mp4BoxAppend(&MovieHeaderBox{Timescale: 1000, NextTrackID: 2})
+			moov.Mp4BoxAppend(trak)
+
+			var buf bytes.Buffer
+			moov.Mp4BoxUpdate()
+			if err := moov.Mp4BoxWrite(&buf); err != nil {
+				t.Fatal(err)
+			}
+			movie, err := ReadMovie(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(movie.Tracks) != 1 {
+				t.Fatalf("movie has %d tracks, want 1", len(movie.Tracks))
+			}
+			if movie.Tracks[0].Codec != test.want {
+				t.Errorf("codec is %v, want %v", movie.Tracks[0].Codec, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// unityMatrix is the identity transformation matrix of movie and track
+// headers.
+var unityMatrix = [9]int32{0x10000, 0, 0, 0, 0x10000, 0, 0, 0, 0x40000000}
+
+// MuxerTrack defines a track written by a Muxer.
+type MuxerTrack struct {
+	// describes the samples of the track, e.g. a visual sample entry of type
+	// ‘avc1’.
+	SampleEntry Box
+
+	// the number of time units of the samples of the track that pass in one
+	// second.
+	Timescale uint32
+
+	// is ‘vide’ for a visual and ‘soun’ for an audio sample entry if not set.
+	HandlerType FourCC
+
+	Language language.Base
+}
+
+// Muxer writes a progressive file from the samples of several tracks. The
+// samples of each track are grouped into chunks, which are written in the
+// order in which they fill up, so that the tracks are interleaved. The
+// movie box is written before the media data box; as its size is only known
+// once all samples are written, the media data is staged until Close.
+type Muxer struct {
+	// is written at the start of the file. NewMuxer sets the brands ‘isom’,
+	// ‘iso2’ and ‘mp41’.
+	FileType *FileTypeBox
+
+	// the timescale of the movie header, which is 1000 unless set.
+	Timescale uint32
+
+	// a chunk is written once its samples last ChunkDuration, which is one
+	// second unless set, or once its data reaches ChunkSize bytes if that is
+	// not 0.
+	ChunkDuration time.Duration
+	ChunkSize     uint32
+
+	w         io.Writer
+	media     io.ReadWriteSeeker
+	buffer    bytes.Buffer
+	mediaSize uint64
+	tracks    []*muxerTrack
+}
+
+type muxerTrack struct {
+	MuxerTrack
+
+	trackID       uint32
+	samples       SampleTableBuilder
+	chunk         []MediaSample
+	chunkDuration uint64
+	chunkSize     uint64
+}
+
+// NewMuxer returns a muxer writing the file to w. The media data is staged in
+// media, which must be empty, e.g. a temporary file, or in memory if media is
+// nil.
+func NewMuxer(w io.Writer, media io.ReadWriteSeeker) *Muxer {
+	return &Muxer{
+		FileType:      newProgressiveFileType(),
+		Timescale:     1000,
+		ChunkDuration: time.Second,
+		w:             w,
+		media:         media,
+	}
+}
+
+// AddTrack adds a track and returns its track ID, which counts the tracks
+// from 1.
+func (m *Muxer) AddTrack(track MuxerTrack) (trackID uint32, err error) {
+	if track.SampleEntry == nil || track.Timescale == 0 {
+		err = fmt.Errorf("track needs a sample entry and a timescale: %w", ErrInvalidFormat)
+		return
+	}
+	if track.HandlerType == (FourCC{}) {
+		switch track.SampleEntry.(type) {
+		case *VisualSampleEntryBox:
+			track.HandlerType = VideFourCC
+		case *AudioSampleEntryBox:
+			track.HandlerType = SounFourCC
+		default:
+			err = fmt.Errorf("handler type of %s sample entry is unknown: %w", track.SampleEntry.Mp4BoxType(), ErrInvalidFormat)
+			return
+		}
+	}
+	trackID = uint32(len(m.tracks) + 1)
+	m.tracks = append(m.tracks, &muxerTrack{MuxerTrack: track, trackID: trackID})
+	return
+}
+
+// WriteSample appends a sample to the track in decode order. Encrypted
+// samples are not supported.
+func (m *Muxer) WriteSample(trackID uint32, sample MediaSample) (err error) {
+	if trackID == 0 || int(trackID) > len(m.tracks) {
+		err = fmt.Errorf("track %d is not in the movie: %w", trackID, ErrInvalidFormat)
+		return
+	}
+	if sample.Encryption != nil {
+		err = fmt.Errorf("encrypted samples are not supported: %w", ErrInvalidFormat)
+		return
+	}
+	track := m.tracks[trackID-1]
+	track.chunk = append(track.chunk, sample)
+	track.chunkDuration += uint64(sample.Duration)
+	track.chunkSize += uint64(len(sample.Data))
+	if scaleDuration(track.chunkDuration, track.Timescale) >= m.ChunkDuration ||
+		(m.ChunkSize != 0 && track.chunkSize >= uint64(m.ChunkSize)) {
+		err = m.writeChunk(track)
+	}
+	return
+}
+
+// writeChunk stages the data of the pending chunk of the track.
+func (m *Muxer) writeChunk(track *muxerTrack) (err error) {
+	media := io.Writer(&m.buffer)
+	if m.media != nil {
+		media = m.media
+	}
+	for _, sample := range track.chunk {
+		track.samples.Add(Sample{
+			Duration:               sample.Duration,
+			CompositionOffset:      sample.CompositionOffset,
+			Size:                   uint32(len(sample.Data)),
+			Offset:                 m.mediaSize,
+			IsSync:                 !sample.Flags.SampleIsNonSyncSample,
+			SampleDescriptionIndex: sample.SampleDescriptionIndex,
+		})
+		if _, err = media.Write(sample.Data); err != nil {
+			return
+		}
+		m.mediaSize += uint64(len(sample.Data))
+	}
+	track.chunk = nil
+	track.chunkDuration = 0
+	track.chunkSize = 0
+	return
+}
+
+// Close writes the pending chunks and then the file type box, the movie box
+// and the media data box to the writer. It does not close the writer.
+func (m *Muxer) Close() (err error) {
+	for _, track := range m.tracks {
+		if err = m.writeChunk(track); err != nil {
+			return
+		}
+	}
+	moov := m.movieBox()
+
+	ftypSize := uint64(m.FileType.Mp4BoxUpdate())
+	moovSize := uint64(moov.Mp4BoxUpdate())
+	mdatHeaderSize := mediaDataHeaderSize(m.mediaSize)
+	if err = moov.RelocateChunkOffsets(func(offset uint64) uint64 {
+		return ftypSize + moovSize + mdatHeaderSize + offset
+	}); err != nil {
+		return
+	}
+	if err = fitMovieBox(moov, ftypSize, moovSize); err != nil {
+		return
+	}
+
+	if err = m.FileType.Mp4BoxWrite(m.w); err != nil {
+		return
+	}
+	if err = moov.Mp4BoxWrite(m.w); err != nil {
+		return
+	}
+	if err = writeMediaDataHeader(m.w, m.mediaSize); err != nil {
+		return
+	}
+	if m.media == nil {
+		_, err = io.Copy(m.w, &m.buffer)
+		return
+	}
+	if _, err = m.media.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	_, err = io.CopyN(m.w, m.media, int64(m.mediaSize))
+	return
+}
+
+// movieBox returns the movie box describing the samples written, with chunk
+// offsets relative to the start of the media data.
+func (m *Muxer) movieBox() *MovieBox {
+	mvhd := &MovieHeaderBox{
+		Timescale:   m.Timescale,
+		Rate:        0x10000,
+		Volume:      0x100,
+		Matrix:      unityMatrix,
+		NextTrackID: uint32(len(m.tracks) + 1),
+	}
+	moov := &MovieBox{}
+	moov.Mp4BoxAppend(mvhd)
+	for _, track := range m.tracks {
+		trak := track.trackBox(m.Timescale)
+		if duration := trak.TrackHeader().Duration; duration > mvhd.Duration {
+			mvhd.Duration = duration
+		}
+		moov.Mp4BoxAppend(trak)
+	}
+	if mvhd.Duration > 0xFFFFFFFF {
+		mvhd.Version = 1
+	}
+	return moov
+}
+
+// trackBox returns the track box of the track, with its duration expressed in
+// the movie timescale in the track header.
+func (t *muxerTrack) trackBox(movieTimescale uint32) *TrackBox {
+	mdhd := &MediaHeaderBox{
+		Timescale: t.Timescale,
+		Duration:  t.samples.Duration(),
+		Language:  t.Language,
+	}
+	if mdhd.Duration > 0xFFFFFFFF {
+		mdhd.Version = 1
+	}
+	tkhd := &TrackHeaderBox{
+		TrackID:  t.trackID,
+		Duration: mdhd.Duration * uint64(movieTimescale) / uint64(t.Timescale),
+		Matrix:   unityMatrix,
+	}
+	tkhd.Mp4BoxSetFlags(FLAG_TKHD_TRACK_ENABLED | FLAG_TKHD_TRACK_IN_MOVIE)
+	if tkhd.Duration > 0xFFFFFFFF {
+		tkhd.Version = 1
+	}
+
+	minf := &MediaInformationBox{}
+	switch t.HandlerType {
+	case VideFourCC:
+		vmhd := &VideoMediaHeaderBox{}
+		vmhd.Mp4BoxSetFlags(1)
+		minf.Mp4BoxAppend(vmhd)
+	case SounFourCC:
+		tkhd.Volume = 0x100
+		minf.Mp4BoxAppend(&SoundMediaHeaderBox{})
+	case SubtFourCC:
+		minf.Mp4BoxAppend(&SubtitleMediaHeaderBox{})
+	default:
+		minf.Mp4BoxAppend(&NullMediaHeaderBox{})
+	}
+	if visual, ok := t.SampleEntry.(*VisualSampleEntryBox); ok {
+		tkhd.Width = uint32(visual.Width) << 16
+		tkhd.Height = uint32(visual.Height) << 16
+	}
+
+	stsd := &SampleDescriptionBox{}
+	stsd.Mp4BoxAppend(t.SampleEntry)
+	stbl := &SampleTableBox{}
+	stbl.Mp4BoxAppend(stsd)
+	t.samples.Build(stbl)
+	minf.Mp4BoxAppend(newDataInformationBox())
+	minf.Mp4BoxAppend(stbl)
+
+	mdia := &MediaBox{}
+	mdia.Mp4BoxAppend(mdhd)
+	mdia.Mp4BoxAppend(&HandlerBox{HandlerType: t.HandlerType})
+	mdia.Mp4BoxAppend(minf)
+	trak := &TrackBox{}
+	trak.Mp4BoxAppend(tkhd)
+	trak.Mp4BoxAppend(mdia)
+	return trak
+}
+
+// newProgressiveFileType returns a file type box with the brands ‘isom’,
+// ‘iso2’ and ‘mp41’.
+func newProgressiveFileType() *FileTypeBox {
+	return &FileTypeBox{
+		MajorBrand:       IsomFourCC,
+		MinorVersion:     0x200,
+		CompatibleBrands: []FourCC{IsomFourCC, Iso2FourCC, Mp41FourCC},
+	}
+}
+
+// mediaDataHeaderSize returns the size of the header of a media data box
+// holding size bytes, which has a 64‐bit size if needed.
+func mediaDataHeaderSize(size uint64) uint64 {
+	if size+8 > 0xFFFFFFFF {
+		return 16
+	}
+	return 8
+}
+
+// writeMediaDataHeader writes the header of a media data box holding size
+// bytes, with a 64‐bit size if needed.
+func writeMediaDataHeader(w io.Writer, size uint64) (err error) {
+	headerSize := mediaDataHeaderSize(size)
+	if headerSize == 8 {
+		header := Header{Size: uint32(8 + size), Type: MdatBoxType}
+		return header.WriteHeader(w)
+	}
+	header := Header{Size: 1, Type: MdatBoxType}
+	if err = header.WriteHeader(w); err != nil {
+		return
+	}
+	return binary.Write(w, binary.BigEndian, headerSize+size)
+}
@@ -37,3 +37,21 @@ func (s NullTerminatedString) Write(w io.Writer) (err error) {
 	}
 	return
 }
+
+// Read reads the string up to and including the null terminator, for strings
+// whose size is not known in advance.
+func (s *NullTerminatedString) Read(r io.Reader) (err error) {
+	var b []byte
+	c := make([]byte, 1)
+	for {
+		if _, err = io.ReadFull(r, c); err != nil {
+			return
+		}
+		if c[0] == 0 {
+			break
+		}
+		b = append(b, c[0])
+	}
+	*s = NullTerminatedString(b)
+	return
+}
@@ -0,0 +1,34 @@
+package mp4
+
+import (
+	"golang.org/x/text/language"
+)
+
+// readPackedLanguage decodes an ISO 639‐2/T language code packed into 15 bits,
+// where each character is stored as the difference between its ASCII value
+// and 0x60. Values below 0x400 are QuickTime Macintosh language codes, of
+// which only 0 (English) is mapped; the others and 0x7FFF decode as
+// undetermined.
+func readPackedLanguage(lang uint16) (base language.Base, err error) {
+	lang &= 0x7FFF
+	if lang == 0 {
+		return language.ParseBase("en")
+	}
+	if lang < 0x400 || lang == 0x7FFF {
+		return language.ParseBase("und")
+	}
+	return language.ParseBase(string([]byte{
+		(byte(lang>>10) & 0x1F) + 0x60,
+		(byte(lang>>5) & 0x1F) + 0x60,
+		(byte(lang) & 0x1F) + 0x60,
+	}))
+}
+
+// packLanguage encodes the ISO 639‐2/T code of the language into 15 bits.
+func packLanguage(base language.Base) uint16 {
+	iso3 := base.ISO3()
+	if len(iso3) != 3 {
+		iso3 = "und"
+	}
+	return uint16(iso3[0]-0x60)<<10 | uint16(iso3[1]-0x60)<<5 | uint16(iso3[2]-0x60)
+}
@@ -0,0 +1,130 @@
+package mp4
+
+// 8.8.3.1 Sample flags
+
+// The sample flags field in sample fragments (default_sample_flags in a Track
+// Extends Box and in a Track Fragment Header Box, and sample_flags and
+// first_sample_flags in a Track Fragment Run Box) is coded as a 32‐bit value.
+// It has the following structure:
+//
+//     bit(4) reserved=0;
+//     unsigned int(2) is_leading;
+//     unsigned int(2) sample_depends_on;
+//     unsigned int(2) sample_is_depended_on;
+//     unsigned int(2) sample_has_redundancy;
+//     bit(3) sample_padding_value;
+//     bit(1) sample_is_non_sync_sample;
+//     unsigned int(16) sample_degradation_priority;
+//
+// The is_leading, sample_depends_on, sample_is_depended_on and
+// sample_has_redundancy values are defined as documented in the Independent and
+// Disposable Samples Box.
+type SampleFlags struct {
+	IsLeading           SampleIsLeading
+	SampleDependsOn     SampleDependsOn
+	SampleIsDependedOn  SampleIsDependedOn
+	SampleHasRedundancy SampleHasRedundancy
+
+	// is defined as for the padding bits table.
+	SamplePaddingValue uint8
+
+	// provides the same information as the sync sample table. When this value
+	// is set 0 for a sample, it is the same as if the sample were not in a
+	// movie fragment and marked with an entry in the sync sample table.
+	SampleIsNonSyncSample bool
+
+	// is defined as for the degradation priority table.
+	SampleDegradationPriority uint16
+}
+
+type SampleIsLeading uint8
+
+const (
+	// the leading nature of this sample is unknown
+	SampleIsLeadingUnknown SampleIsLeading = 0
+
+	// this sample is a leading sample that has a dependency before the
+	// referenced I‐picture (and is therefore not decodable)
+	SampleIsLeadingWithDependency SampleIsLeading = 1
+
+	// this sample is not a leading sample
+	SampleIsNotLeading SampleIsLeading = 2
+
+	// this sample is a leading sample that has no dependency before the
+	// referenced I‐picture (and is therefore decodable)
+	SampleIsLeadingWithoutDependency SampleIsLeading = 3
+)
+
+type SampleDependsOn uint8
+
+const (
+	// the dependency of this sample is unknown
+	SampleDependsOnUnknown SampleDependsOn = 0
+
+	// this sample does depend on others (not an I picture)
+	SampleDependsOnOthers SampleDependsOn = 1
+
+	// this sample does not depend on others (I picture)
+	SampleDependsOnNoOthers SampleDependsOn = 2
+)
+
+type SampleIsDependedOn uint8
+
+const (
+	// the dependency of other samples on this sample is unknown
+	SampleIsDependedOnUnknown SampleIsDependedOn = 0
+
+	// other samples may depend on this one (not disposable)
+	SampleIsDependedOnByOthers SampleIsDependedOn = 1
+
+	// no other sample depends on this one (disposable)
+	SampleIsNotDependedOn SampleIsDependedOn = 2
+)
+
+type SampleHasRedundancy uint8
+
+const (
+	// it is unknown whether there is redundant coding in this sample
+	SampleHasRedundancyUnknown SampleHasRedundancy = 0
+
+	// there is redundant coding in this sample
+	SampleHasRedundantCoding SampleHasRedundancy = 1
+
+	// there is no redundant coding in this sample
+	SampleHasNoRedundantCoding SampleHasRedundancy = 2
+)
+
+func DecodeSampleFlags(flags uint32) (f SampleFlags) {
+	f.IsLeading = SampleIsLeading((flags >> 26) & 0b11)
+	f.SampleDependsOn = SampleDependsOn((flags >> 24) & 0b11)
+	f.SampleIsDependedOn = SampleIsDependedOn((flags >> 22) & 0b11)
+	f.SampleHasRedundancy = SampleHasRedundancy((flags >> 20) & 0b11)
+	f.SamplePaddingValue = uint8((flags >> 17) & 0b111)
+	f.SampleIsNonSyncSample = (flags>>16)&0b1 > 0
+	f.SampleDegradationPriority = uint16(flags)
+	return
+}
+
+func (f SampleFlags) Encode() (flags uint32) {
+	flags |= uint32(f.IsLeading&0b11) << 26
+	flags |= uint32(f.SampleDependsOn&0b11) << 24
+	flags |= uint32(f.SampleIsDependedOn&0b11) << 22
+	flags |= uint32(f.SampleHasRedundancy&0b11) << 20
+	flags |= uint32(f.SamplePaddingValue&0b111) << 17
+	if f.SampleIsNonSyncSample {
+		flags |= 1 << 16
+	}
+	flags |= uint32(f.SampleDegradationPriority)
+	return
+}
+
+// IsSync reports whether the sample is a sync sample.
+func (f SampleFlags) IsSync() bool {
+	return !f.SampleIsNonSyncSample
+}
+
+// IsDisposable reports whether no other sample depends on this one, so that it
+// can be dropped, e.g. for trick play.
+func (f SampleFlags) IsDisposable() bool {
+	return f.SampleIsDependedOn == SampleIsNotDependedOn
+}
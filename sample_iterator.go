@@ -0,0 +1,333 @@
+package mp4
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sample describes a sample of a track and its location in the file.
+type Sample struct {
+	// is the 1‐based number of the sample in the track.
+	Number uint32
+
+	// is the decode time of the sample in units of the media timescale.
+	DecodeTime uint64
+
+	// is the offset of the composition time from the decode time.
+	CompositionOffset int64
+
+	// is the duration of the sample in units of the media timescale.
+	Duration uint32
+
+	Size uint32
+
+	// is the absolute offset of the sample in the file.
+	Offset uint64
+
+	// indicates a sync sample, i.e. a random access point.
+	IsSync bool
+
+	// is the 1‐based index of the sample entry describing the sample.
+	SampleDescriptionIndex uint32
+}
+
+// CompositionTime returns the composition time of the sample in units of the
+// media timescale.
+func (s *Sample) CompositionTime() int64 {
+	return int64(s.DecodeTime) + s.CompositionOffset
+}
+
+// SampleIterator walks the samples of a track of a non‐fragmented file, as
+// given by the tables of its sample table box. The tables are indexed once
+// when the iterator is created, after which Next returns each sample in
+// constant time and Seek positions the iterator in O(log n).
+type SampleIterator struct {
+	stsz         *SampleSizeBox
+	stts         []TimeToSampleEntry
+	ctts         []CompositionOffsetEntry
+	stss         []uint32
+	chunkOffsets []uint64
+	chunkRuns    []chunkRun
+
+	// hold the 0‐based number of the first sample of each table entry, and
+	// the decode time of the first sample of each ‘stts’ entry.
+	sttsFirst []uint32
+	sttsTime  []uint64
+	cttsFirst []uint32
+
+	// holds the total size of the samples preceding each sample if the
+	// samples differ in size.
+	sizeSums []uint64
+
+	count  uint32
+	number uint32 // 0‐based number of the next sample
+
+	// cursors into the tables for the next sample.
+	sttsIndex  int
+	cttsIndex  int
+	stssIndex  int
+	runIndex   int
+	chunk      uint32
+	nextOffset uint64
+}
+
+// chunkRun is a run of chunks with the same number of samples and sample
+// entry, as given by an entry of the ‘stsc’ box.
+type chunkRun struct {
+	firstChunk      uint32 // 0‐based
+	firstSample     uint32 // 0‐based
+	samplesPerChunk uint32
+	descIndex       uint32
+}
+
+// NewSampleIterator indexes the ‘stts’, ‘ctts’, ‘stsc’, ‘stsz’, ‘stco’ or
+// ‘co64’ and ‘stss’ boxes of the sample table. The ‘ctts’ and ‘stss’ boxes
+// are optional; without ‘stss’ every sample is a sync sample.
+func NewSampleIterator(stbl *SampleTableBox) (it *SampleIterator, err error) {
+	it = &SampleIterator{}
+	var ok bool
+	if it.stsz, ok = stbl.Mp4BoxFindFirst(StszBoxType).(*SampleSizeBox); !ok {
+		err = fmt.Errorf("sample table has no stsz box: %w", ErrInvalidFormat)
+		return
+	}
+	it.count = it.stsz.NumSamples()
+	if it.stsz.SampleSize == 0 {
+		it.sizeSums = make([]uint64, it.count+1)
+		for i, entry := range it.stsz.Entries {
+			it.sizeSums[i+1] = it.sizeSums[i] + uint64(entry.EntrySize)
+		}
+	}
+
+	stts, ok := stbl.Mp4BoxFindFirst(SttsBoxType).(*TimeToSampleBox)
+	if !ok {
+		err = fmt.Errorf("sample table has no stts box: %w", ErrInvalidFormat)
+		return
+	}
+	it.stts = stts.Entries
+	it.sttsFirst = make([]uint32, len(it.stts)+1)
+	it.sttsTime = make([]uint64, len(it.stts)+1)
+	for i, entry := range it.stts {
+		it.sttsFirst[i+1] = it.sttsFirst[i] + entry.SampleCount
+		it.sttsTime[i+1] = it.sttsTime[i] + uint64(entry.SampleCount)*uint64(entry.SampleDelta)
+	}
+	if it.sttsFirst[len(it.stts)] < it.count {
+		err = fmt.Errorf("stts box covers %d of %d samples: %w", it.sttsFirst[len(it.stts)], it.count, ErrInvalidFormat)
+		return
+	}
+
+	if ctts, ok := stbl.Mp4BoxFindFirst(CttsBoxType).(*CompositionOffsetBox); ok {
+		it.ctts = ctts.Entries
+		it.cttsFirst = make([]uint32, len(it.ctts)+1)
+		for i, entry := range it.ctts {
+			it.cttsFirst[i+1] = it.cttsFirst[i] + entry.SampleCount
+		}
+	}
+
+	if stss, ok := stbl.Mp4BoxFindFirst(StssBoxType).(*SyncSampleBox); ok {
+		it.stss = stss.SampleNumbers
+		if it.stss == nil {
+			it.stss = []uint32{}
+		}
+	}
+
+	if it.chunkOffsets, err = chunkOffsets(stbl); err != nil {
+		return
+	}
+
+	stsc, ok := stbl.Mp4BoxFindFirst(StscBoxType).(*SampleToChunkBox)
+	if !ok {
+		err = fmt.Errorf("sample table has no stsc box: %w", ErrInvalidFormat)
+		return
+	}
+	var sample uint64
+	chunkCount := uint32(len(it.chunkOffsets))
+	for i, entry := range stsc.Entries {
+		lastChunk := chunkCount
+		if i+1 < len(stsc.Entries) {
+			lastChunk = stsc.Entries[i+1].FirstChunk - 1
+		}
+		if entry.FirstChunk == 0 || entry.FirstChunk > lastChunk+1 || lastChunk > chunkCount {
+			err = fmt.Errorf("stsc box references missing chunk: %w", ErrInvalidFormat)
+			return
+		}
+		if entry.SamplesPerChunk == 0 {
+			err = fmt.Errorf("stsc box has chunks without samples: %w", ErrInvalidFormat)
+			return
+		}
+		it.chunkRuns = append(it.chunkRuns, chunkRun{
+			firstChunk:      entry.FirstChunk - 1,
+			firstSample:     uint32(sample),
+			samplesPerChunk: entry.SamplesPerChunk,
+			descIndex:       entry.SampleDescrptionIndex,
+		})
+		sample += uint64(lastChunk-entry.FirstChunk+1) * uint64(entry.SamplesPerChunk)
+		if sample >= uint64(it.count) {
+			break
+		}
+	}
+	if sample < uint64(it.count) {
+		err = fmt.Errorf("sample table has %d samples outside of chunks: %w", uint64(it.count)-sample, ErrInvalidFormat)
+		return
+	}
+	it.Seek(1)
+	return
+}
+
+// chunkOffsets returns the chunk offsets of the ‘stco’ or ‘co64’ box.
+func chunkOffsets(stbl *SampleTableBox) (offsets []uint64, err error) {
+	if stco, ok := stbl.Mp4BoxFindFirst(StcoBoxType).(*ChunkOffsetBox); ok {
+		offsets = make([]uint64, len(stco.Entries))
+		for i, entry := range stco.Entries {
+			offsets[i] = uint64(entry.ChunkOffset)
+		}
+		return
+	}
+	if co64, ok := stbl.Mp4BoxFindFirst(Co64BoxType).(*ChunkLargeOffsetBox); ok {
+		offsets = make([]uint64, len(co64.Entries))
+		for i, entry := range co64.Entries {
+			offsets[i] = entry.ChunkOffset
+		}
+		return
+	}
+	err = fmt.Errorf("sample table has no stco or co64 box: %w", ErrInvalidFormat)
+	return
+}
+
+// Samples returns an iterator over the samples of the track.
+func (b *TrackBox) Samples() (*SampleIterator, error) {
+	stbl := b.SampleTable()
+	if stbl == nil {
+		return nil, fmt.Errorf("track has no sample table: %w", ErrInvalidFormat)
+	}
+	return NewSampleIterator(stbl)
+}
+
+// SampleCount returns the number of samples of the track.
+func (it *SampleIterator) SampleCount() uint32 {
+	return it.count
+}
+
+// Seek positions the iterator so that Next returns the sample with the given
+// 1‐based number. Seeking to SampleCount()+1 positions the iterator at the
+// end.
+func (it *SampleIterator) Seek(number uint32) (err error) {
+	if number == 0 || number > it.count+1 {
+		err = fmt.Errorf("sample number %d out of range 1..%d", number, it.count)
+		return
+	}
+	it.number = number - 1
+	if it.number == it.count {
+		return
+	}
+	it.sttsIndex = sort.Search(len(it.stts), func(i int) bool { return it.sttsFirst[i+1] > it.number })
+	it.cttsIndex = sort.Search(len(it.ctts), func(i int) bool { return it.cttsFirst[i+1] > it.number })
+	it.stssIndex = sort.Search(len(it.stss), func(i int) bool { return it.stss[i] >= number })
+	it.runIndex = sort.Search(len(it.chunkRuns), func(i int) bool { return it.chunkRuns[i].firstSample > it.number }) - 1
+	run := &it.chunkRuns[it.runIndex]
+	inRun := it.number - run.firstSample
+	it.chunk = run.firstChunk + inRun/run.samplesPerChunk
+	it.nextOffset = it.chunkOffsets[it.chunk] + it.sizeBetween(it.number-inRun%run.samplesPerChunk, it.number)
+	return
+}
+
+// sizeBetween returns the total size of the samples with 0‐based numbers from
+// first up to but excluding last.
+func (it *SampleIterator) sizeBetween(first, last uint32) uint64 {
+	if it.sizeSums == nil {
+		return uint64(last-first) * uint64(it.stsz.SampleSize)
+	}
+	return it.sizeSums[last] - it.sizeSums[first]
+}
+
+// Next returns the next sample, or false once all samples are returned.
+func (it *SampleIterator) Next() (sample Sample, ok bool) {
+	if it.number >= it.count {
+		return
+	}
+	n := it.number
+	for it.sttsFirst[it.sttsIndex+1] <= n {
+		it.sttsIndex++
+	}
+	for it.cttsIndex < len(it.ctts) && it.cttsFirst[it.cttsIndex+1] <= n {
+		it.cttsIndex++
+	}
+	for it.runIndex+1 < len(it.chunkRuns) && it.chunkRuns[it.runIndex+1].firstSample <= n {
+		it.runIndex++
+	}
+	run := &it.chunkRuns[it.runIndex]
+	if chunk := run.firstChunk + (n-run.firstSample)/run.samplesPerChunk; chunk != it.chunk {
+		it.chunk = chunk
+		it.nextOffset = it.chunkOffsets[chunk]
+	}
+	stts := &it.stts[it.sttsIndex]
+	sample = Sample{
+		Number:                 n + 1,
+		DecodeTime:             it.sttsTime[it.sttsIndex] + uint64(n-it.sttsFirst[it.sttsIndex])*uint64(stts.SampleDelta),
+		Duration:               stts.SampleDelta,
+		Size:                   it.stsz.SampleSizeAt(n),
+		Offset:                 it.nextOffset,
+		IsSync:                 true,
+		SampleDescriptionIndex: run.descIndex,
+	}
+	if it.cttsIndex < len(it.ctts) {
+		sample.CompositionOffset = it.ctts[it.cttsIndex].SampleOffset
+	}
+	if it.stss != nil {
+		for it.stssIndex < len(it.stss) && it.stss[it.stssIndex] < n+1 {
+			it.stssIndex++
+		}
+		sample.IsSync = it.stssIndex < len(it.stss) && it.stss[it.stssIndex] == n+1
+	}
+	it.nextOffset += uint64(sample.Size)
+	it.number++
+	ok = true
+	return
+}
+
+// Duration returns the sum of the durations of the samples.
+func (it *SampleIterator) Duration() uint64 {
+	n := sort.Search(len(it.stts), func(i int) bool { return it.sttsFirst[i+1] >= it.count })
+	if n == len(it.stts) {
+		return it.sttsTime[n]
+	}
+	return it.sttsTime[n] + uint64(it.count-it.sttsFirst[n])*uint64(it.stts[n].SampleDelta)
+}
+
+// SampleNumberAt returns the number of the sample being decoded at the given
+// decode time, i.e. the last sample whose decode time is not after it. It
+// returns 0 if there are no samples.
+func (it *SampleIterator) SampleNumberAt(decodeTime uint64) uint32 {
+	if it.count == 0 {
+		return 0
+	}
+	i := sort.Search(len(it.stts), func(i int) bool { return it.sttsTime[i+1] > decodeTime })
+	var number uint32
+	if i == len(it.stts) {
+		number = it.sttsFirst[i]
+	} else {
+		number = it.sttsFirst[i] + 1
+		if delta := uint64(it.stts[i].SampleDelta); delta > 0 {
+			number += uint32((decodeTime - it.sttsTime[i]) / delta)
+		}
+	}
+	if number > it.count {
+		number = it.count
+	}
+	return number
+}
+
+// SyncSampleAtOrBefore returns the number of the last sync sample not after
+// the sample with the given number, or 0 if there is none.
+func (it *SampleIterator) SyncSampleAtOrBefore(number uint32) uint32 {
+	if number > it.count {
+		number = it.count
+	}
+	if it.stss == nil {
+		return number
+	}
+	i := sort.Search(len(it.stss), func(i int) bool { return it.stss[i] > number })
+	if i == 0 {
+		return 0
+	}
+	return it.stss[i-1]
+}
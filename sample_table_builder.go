@@ -0,0 +1,177 @@
+package mp4
+
+// SampleTableBuilder collects the samples of a track in decode order and
+// builds the minimal set of sample table boxes describing them. Consecutive
+// samples are placed in the same chunk when each one starts where the previous
+// one ends in the file and both use the same sample entry.
+type SampleTableBuilder struct {
+	count    uint32
+	duration uint64
+
+	stts []TimeToSampleEntry
+	ctts []CompositionOffsetEntry
+	stsc []SampleToChunkEntry
+	stss []uint32
+
+	sizes        []SampleSizeEntry
+	chunkOffsets []uint64
+
+	// hold the number of samples and the sample entry of the last chunk, and
+	// the file position following its last sample.
+	chunkSamples uint32
+	chunkDesc    uint32
+	chunkEnd     uint64
+}
+
+// Add appends a sample. Its Duration, CompositionOffset, Size, Offset, IsSync
+// and SampleDescriptionIndex are used; a SampleDescriptionIndex of 0 means 1.
+func (b *SampleTableBuilder) Add(sample Sample) {
+	desc := sample.SampleDescriptionIndex
+	if desc == 0 {
+		desc = 1
+	}
+	b.count++
+	b.duration += uint64(sample.Duration)
+
+	if n := len(b.stts); n > 0 && b.stts[n-1].SampleDelta == sample.Duration {
+		b.stts[n-1].SampleCount++
+	} else {
+		b.stts = append(b.stts, TimeToSampleEntry{SampleCount: 1, SampleDelta: sample.Duration})
+	}
+	if n := len(b.ctts); n > 0 && b.ctts[n-1].SampleOffset == sample.CompositionOffset {
+		b.ctts[n-1].SampleCount++
+	} else {
+		b.ctts = append(b.ctts, CompositionOffsetEntry{SampleCount: 1, SampleOffset: sample.CompositionOffset})
+	}
+	if sample.IsSync {
+		b.stss = append(b.stss, b.count)
+	}
+	b.sizes = append(b.sizes, SampleSizeEntry{EntrySize: sample.Size})
+
+	if b.chunkSamples == 0 || sample.Offset != b.chunkEnd || desc != b.chunkDesc {
+		b.closeChunk()
+		b.chunkOffsets = append(b.chunkOffsets, sample.Offset)
+		b.chunkDesc = desc
+	}
+	b.chunkSamples++
+	b.chunkEnd = sample.Offset + uint64(sample.Size)
+}
+
+// closeChunk records the last chunk in the sample‐to‐chunk table.
+func (b *SampleTableBuilder) closeChunk() {
+	if b.chunkSamples == 0 {
+		return
+	}
+	b.stsc = b.appendChunk(b.stsc)
+	b.chunkSamples = 0
+}
+
+// appendChunk adds the last chunk to the sample‐to‐chunk entries unless it
+// continues the run of the last entry.
+func (b *SampleTableBuilder) appendChunk(entries []SampleToChunkEntry) []SampleToChunkEntry {
+	if n := len(entries); n > 0 && entries[n-1].SamplesPerChunk == b.chunkSamples && entries[n-1].SampleDescrptionIndex == b.chunkDesc {
+		return entries
+	}
+	return append(entries, SampleToChunkEntry{
+		FirstChunk:            uint32(len(b.chunkOffsets)),
+		SamplesPerChunk:       b.chunkSamples,
+		SampleDescrptionIndex: b.chunkDesc,
+	})
+}
+
+// SampleCount returns the number of samples added.
+func (b *SampleTableBuilder) SampleCount() uint32 {
+	return b.count
+}
+
+// Duration returns the sum of the durations of the samples added.
+func (b *SampleTableBuilder) Duration() uint64 {
+	return b.duration
+}
+
+// Boxes returns the sample table boxes in their usual order: ‘stts’, ‘ctts’
+// if any sample has a composition offset, ‘stsc’, ‘stsz’, ‘stss’ if any
+// sample is not a sync sample, and ‘stco’, or ‘co64’ if an offset does not
+// fit into 32 bits. ‘ctts’ is version 1 if an offset is negative, and ‘stsz’
+// uses its constant size form if all samples have the same size.
+func (b *SampleTableBuilder) Boxes() (boxes []Box) {
+	boxes = append(boxes, &TimeToSampleBox{Entries: append([]TimeToSampleEntry(nil), b.stts...)})
+
+	var hasOffset, hasNegative bool
+	for _, entry := range b.ctts {
+		hasOffset = hasOffset || entry.SampleOffset != 0
+		hasNegative = hasNegative || entry.SampleOffset < 0
+	}
+	if hasOffset {
+		ctts := &CompositionOffsetBox{Entries: append([]CompositionOffsetEntry(nil), b.ctts...)}
+		if hasNegative {
+			ctts.Version = 1
+		}
+		boxes = append(boxes, ctts)
+	}
+
+	stsc := &SampleToChunkBox{Entries: append([]SampleToChunkEntry(nil), b.stsc...)}
+	if b.chunkSamples > 0 {
+		stsc.Entries = b.appendChunk(stsc.Entries)
+	}
+	boxes = append(boxes, stsc)
+
+	stsz := &SampleSizeBox{}
+	constant := len(b.sizes) > 0 && b.sizes[0].EntrySize != 0
+	for _, entry := range b.sizes {
+		if entry.EntrySize != b.sizes[0].EntrySize {
+			constant = false
+			break
+		}
+	}
+	if constant {
+		stsz.SampleSize = b.sizes[0].EntrySize
+		stsz.SampleCount = b.count
+	} else {
+		stsz.Entries = append([]SampleSizeEntry(nil), b.sizes...)
+	}
+	boxes = append(boxes, stsz)
+
+	if uint32(len(b.stss)) != b.count {
+		boxes = append(boxes, &SyncSampleBox{SampleNumbers: append([]uint32{}, b.stss...)})
+	}
+
+	boxes = append(boxes, newChunkOffsetBox(b.chunkOffsets))
+	return
+}
+
+// Build replaces the ‘stts’, ‘ctts’, ‘stsc’, ‘stsz’, ‘stss’, ‘stco’ and
+// ‘co64’ boxes of the sample table with those returned by Boxes. Other boxes,
+// such as the sample description box, are kept.
+func (b *SampleTableBuilder) Build(stbl *SampleTableBox) {
+	for _, boxType := range []BoxType{SttsBoxType, CttsBoxType, StscBoxType, StszBoxType, StssBoxType, StcoBoxType, Co64BoxType} {
+		removeChildren(&stbl.Container, boxType, func(Box) bool { return true })
+	}
+	for _, box := range b.Boxes() {
+		stbl.Mp4BoxAppend(box)
+	}
+}
+
+// newChunkOffsetBox returns a ‘stco’ box holding the offsets, or a ‘co64’ box
+// if an offset does not fit into 32 bits.
+func newChunkOffsetBox(offsets []uint64) Box {
+	large := false
+	for _, offset := range offsets {
+		if offset > 0xFFFFFFFF {
+			large = true
+			break
+		}
+	}
+	if large {
+		co64 := &ChunkLargeOffsetBox{Entries: make([]ChunkLargeOffsetEntry, len(offsets))}
+		for i, offset := range offsets {
+			co64.Entries[i].ChunkOffset = offset
+		}
+		return co64
+	}
+	stco := &ChunkOffsetBox{Entries: make([]ChunkOffsetEntry, len(offsets))}
+	for i, offset := range offsets {
+		stco.Entries[i].ChunkOffset = uint32(offset)
+	}
+	return stco
+}
@@ -0,0 +1,144 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Timecode is a SMPTE timecode. Drop‐frame timecodes separate the frames with
+// a semicolon when formatted.
+type Timecode struct {
+	Hours     int
+	Minutes   int
+	Seconds   int
+	Frames    int
+	DropFrame bool
+}
+
+func (tc Timecode) String() string {
+	sep := ":"
+	if tc.DropFrame {
+		sep = ";"
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%02d", tc.Hours, tc.Minutes, tc.Seconds, sep, tc.Frames)
+}
+
+// dropFrames returns the number of frame numbers skipped at the start of each
+// minute except every tenth, which is 2 for 29.97 and 4 for 59.94 frames per
+// second, or 0 if the timecode is not drop‐frame.
+func (b *TimecodeSampleEntryBox) dropFrames() int {
+	if b.Flags&TimecodeFlagDropFrame == 0 || b.NumberOfFrames == 0 || b.NumberOfFrames%30 != 0 {
+		return 0
+	}
+	return int(b.NumberOfFrames) / 15
+}
+
+// Timecode converts a frame number, as stored in the samples of the timecode
+// track, into a timecode.
+func (b *TimecodeSampleEntryBox) Timecode(frameNumber uint32) (tc Timecode) {
+	fps := int(b.NumberOfFrames)
+	if fps == 0 {
+		return
+	}
+	frame := int(frameNumber)
+	if drop := b.dropFrames(); drop > 0 {
+		tc.DropFrame = true
+		framesPerMinute := fps*60 - drop
+		framesPer10Minutes := framesPerMinute*10 + drop
+		tens, rem := frame/framesPer10Minutes, frame%framesPer10Minutes
+		frame += drop * 9 * tens
+		if rem > drop {
+			frame += drop * ((rem - drop) / framesPerMinute)
+		}
+	}
+	tc.Frames = frame % fps
+	tc.Seconds = frame / fps % 60
+	tc.Minutes = frame / (fps * 60) % 60
+	tc.Hours = frame / (fps * 3600)
+	if b.Flags&TimecodeFlag24HourMax != 0 {
+		tc.Hours %= 24
+	}
+	return
+}
+
+// FrameNumber converts a timecode into the frame number stored in the samples
+// of the timecode track.
+func (b *TimecodeSampleEntryBox) FrameNumber(tc Timecode) uint32 {
+	fps := int(b.NumberOfFrames)
+	frame := ((tc.Hours*60+tc.Minutes)*60+tc.Seconds)*fps + tc.Frames
+	if drop := b.dropFrames(); drop > 0 {
+		minutes := tc.Hours*60 + tc.Minutes
+		frame -= drop * (minutes - minutes/10)
+	}
+	return uint32(frame)
+}
+
+// TimecodeTrack returns the first timecode track, or nil if there is none.
+func (b *MovieBox) TimecodeTrack() *TrackBox {
+	for _, trak := range b.Tracks() {
+		if hdlr := trak.Handler(); hdlr != nil && hdlr.HandlerType == TmcdFourCC {
+			return trak
+		}
+	}
+	return nil
+}
+
+// StartTimecode returns the timecode of the first sample of the timecode
+// track. The sample is read from r, which must give access to the file holding
+// the movie box. ok is false if the movie has no timecode track.
+func (b *MovieBox) StartTimecode(r io.ReaderAt) (tc Timecode, ok bool, err error) {
+	trak := b.TimecodeTrack()
+	if trak == nil {
+		return
+	}
+	stbl := trak.SampleTable()
+	if stbl == nil {
+		err = fmt.Errorf("timecode track has no sample table: %w", ErrInvalidFormat)
+		return
+	}
+	tmcd := timecodeSampleEntry(stbl)
+	if tmcd == nil {
+		err = fmt.Errorf("timecode track has no tmcd sample entry: %w", ErrInvalidFormat)
+		return
+	}
+	if tmcd.Flags&TimecodeFlagCounter != 0 {
+		err = fmt.Errorf("timecode track holds a counter: %w", ErrInvalidFormat)
+		return
+	}
+	var it *SampleIterator
+	if it, err = NewSampleIterator(stbl); err != nil {
+		return
+	}
+	sample, found := it.Next()
+	if !found || sample.Size < 4 {
+		err = fmt.Errorf("timecode track has no frame number sample: %w", ErrInvalidFormat)
+		return
+	}
+	var data [4]byte
+	if _, err = r.ReadAt(data[:], int64(sample.Offset)); err != nil {
+		return
+	}
+	tc = tmcd.Timecode(binary.BigEndian.Uint32(data[:]))
+	ok = true
+	return
+}
+
+// timecodeSampleEntry returns the sample entry describing the first chunk of
+// the sample table, if it is a timecode sample entry.
+func timecodeSampleEntry(stbl *SampleTableBox) *TimecodeSampleEntryBox {
+	stsd, ok := stbl.Mp4BoxFindFirst(StsdBoxType).(*SampleDescriptionBox)
+	if !ok {
+		return nil
+	}
+	index := 1
+	if stsc, ok := stbl.Mp4BoxFindFirst(StscBoxType).(*SampleToChunkBox); ok && len(stsc.Entries) > 0 {
+		index = int(stsc.Entries[0].SampleDescrptionIndex)
+	}
+	entries := stsd.Mp4BoxChildren()
+	if index < 1 || index > len(entries) {
+		return nil
+	}
+	tmcd, _ := entries[index-1].(*TimecodeSampleEntryBox)
+	return tmcd
+}
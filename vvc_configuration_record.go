@@ -0,0 +1,399 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// ISO/IEC 14496‐15 11.2.4.2 VVC decoder configuration record
+
+// This subclause specifies the decoder configuration information for ISO/IEC
+// 23090‐3 video content. This record contains the size of the length field
+// used in each sample to indicate the length of its contained NAL units as
+// well as the parameter sets, if stored in the sample entry. This record is
+// externally framed (its size shall be supplied by the structure that contains
+// it).
+//
+// When ptl_present_flag is set, the record also describes the output layer set
+// of the stream: its profile, tier and level in a VvcPTLRecord, the chroma
+// format, bit depth and maximum picture size.
+//
+// There is a set of arrays to carry initialization NAL units. The NAL unit
+// types are restricted to indicate DCI, OPI, VPS, SPS, PPS, prefix APS and
+// prefix SEI NAL units, and suffix APS and suffix SEI NAL units that are
+// declarative. DCI and OPI arrays contain exactly one NAL unit and carry no
+// NAL unit count.
+type VVCDecoderConfigurationRecord struct {
+	LengthSizeMinusOne uint8
+	PTLPresentFlag     bool
+
+	// present if PTLPresentFlag is set.
+	OLSIdx            uint16
+	NumSublayers      uint8
+	ConstantFrameRate uint8
+	ChromaFormatIdc   uint8
+	BitDepthMinus8    uint8
+	NativePTL         VVCPTLRecord
+	MaxPictureWidth   uint16
+	MaxPictureHeight  uint16
+	AvgFrameRate      uint16
+
+	NaluArrays []VVCNaluArray
+}
+
+// ISO/IEC 14496‐15 11.2.4.2.2 VvcPTLRecord
+type VVCPTLRecord struct {
+	GeneralProfileIdc uint8
+	GeneralTierFlag   bool
+	GeneralLevelIdc   uint8
+
+	PTLFrameOnlyConstraintFlag bool
+	PTLMultiLayerEnabledFlag   bool
+
+	// holds the num_bytes_constraint_info bytes of the general constraints
+	// information, with the two leading bits, which carry the flags above,
+	// set to zero. It is at least one byte long.
+	GeneralConstraintInfo []byte
+
+	// is indexed by sublayer and has NumSublayers ‐ 1 entries. A level of 0
+	// indicates that ptl_sublayer_level_present_flag is not set.
+	SublayerLevelIdc []uint8
+
+	GeneralSubProfileIdc []uint32
+}
+
+type VVCNaluArray struct {
+	ArrayCompleteness bool
+	NALUnitType       VVCNaluType
+	NALUs             [][]byte
+}
+
+// ISO/IEC 23090‐3 Table 5 NAL unit type codes
+type VVCNaluType uint8
+
+const (
+	VVCNaluOPI       VVCNaluType = 12
+	VVCNaluDCI       VVCNaluType = 13
+	VVCNaluVPS       VVCNaluType = 14
+	VVCNaluSPS       VVCNaluType = 15
+	VVCNaluPPS       VVCNaluType = 16
+	VVCNaluPrefixAPS VVCNaluType = 17
+	VVCNaluSuffixAPS VVCNaluType = 18
+	VVCNaluPH        VVCNaluType = 19
+	VVCNaluAUD       VVCNaluType = 20
+	VVCNaluEOS       VVCNaluType = 21
+	VVCNaluEOB       VVCNaluType = 22
+	VVCNaluPrefixSEI VVCNaluType = 23
+	VVCNaluSuffixSEI VVCNaluType = 24
+	VVCNaluFD        VVCNaluType = 25
+)
+
+func (t VVCNaluType) hasNumNalus() bool {
+	return t != VVCNaluDCI && t != VVCNaluOPI
+}
+
+func (b *VVCDecoderConfigurationRecord) RecordSize() (size uint32) {
+	// bit(5) reserved = '11111'b;
+	// unsigned int(2) LengthSizeMinusOne;
+	// unsigned int(1) ptl_present_flag;
+	size += 1
+	if b.PTLPresentFlag {
+		// unsigned int(9) ols_idx;
+		// unsigned int(3) num_sublayers;
+		// unsigned int(2) constant_frame_rate;
+		// unsigned int(2) chroma_format_idc;
+		// unsigned int(3) bit_depth_minus8;
+		// bit(5) reserved = '11111'b;
+		size += 3
+		size += b.NativePTL.recordSize(b.NumSublayers) // VvcPTLRecord(num_sublayers) native_ptl;
+		size += 2                                      // unsigned int(16) max_picture_width;
+		size += 2                                      // unsigned int(16) max_picture_height;
+		size += 2                                      // unsigned int(16) avg_frame_rate;
+	}
+	size += 1 // unsigned int(8) num_of_arrays;
+	for _, entry := range b.NaluArrays {
+		// unsigned int(1) array_completeness;
+		// bit(2) reserved = 0;
+		// unsigned int(5) NAL_unit_type;
+		size += 1
+		if entry.NALUnitType.hasNumNalus() {
+			size += 2 // unsigned int(16) num_nalus;
+		}
+		for _, nalu := range entry.NALUs {
+			size += 2                 // unsigned int(16) nal_unit_length;
+			size += uint32(len(nalu)) // bit(8*nal_unit_length) nal_unit;
+		}
+	}
+	return
+}
+
+func (b *VVCDecoderConfigurationRecord) RecordRead(r io.Reader) (err error) {
+	var tmp [3]uint8
+	if err = binary.Read(r, binary.BigEndian, tmp[:1]); err != nil {
+		return
+	}
+	b.LengthSizeMinusOne = tmp[0] >> 1 & 0b11
+	b.PTLPresentFlag = tmp[0]&1 != 0
+	if b.PTLPresentFlag {
+		if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+			return
+		}
+		b.OLSIdx = uint16(tmp[0])<<1 | uint16(tmp[1]>>7)
+		b.NumSublayers = tmp[1] >> 4 & 0b111
+		b.ConstantFrameRate = tmp[1] >> 2 & 0b11
+		b.ChromaFormatIdc = tmp[1] & 0b11
+		b.BitDepthMinus8 = tmp[2] >> 5
+		if err = b.NativePTL.recordRead(r, b.NumSublayers); err != nil {
+			return
+		}
+		var tmp2 [3]uint16
+		if err = binary.Read(r, binary.BigEndian, &tmp2); err != nil {
+			return
+		}
+		b.MaxPictureWidth = tmp2[0]
+		b.MaxPictureHeight = tmp2[1]
+		b.AvgFrameRate = tmp2[2]
+	}
+	if err = binary.Read(r, binary.BigEndian, tmp[:1]); err != nil {
+		return
+	}
+	b.NaluArrays = make([]VVCNaluArray, tmp[0])
+	for i := range b.NaluArrays {
+		entry := &b.NaluArrays[i]
+		if err = binary.Read(r, binary.BigEndian, tmp[:1]); err != nil {
+			return
+		}
+		entry.ArrayCompleteness = tmp[0]>>7 != 0
+		entry.NALUnitType = VVCNaluType(tmp[0] & 0b11111)
+		var naluCount uint16 = 1
+		if entry.NALUnitType.hasNumNalus() {
+			if err = binary.Read(r, binary.BigEndian, &naluCount); err != nil {
+				return
+			}
+		}
+		entry.NALUs = make([][]byte, naluCount)
+		for j := range entry.NALUs {
+			var naluLength uint16
+			if err = binary.Read(r, binary.BigEndian, &naluLength); err != nil {
+				return
+			}
+			entry.NALUs[j] = make([]byte, naluLength)
+			if _, err = io.ReadFull(r, entry.NALUs[j]); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (b *VVCDecoderConfigurationRecord) RecordWrite(w io.Writer) (err error) {
+	tmp := 0b11111000 | (b.LengthSizeMinusOne&0b11)<<1
+	if b.PTLPresentFlag {
+		tmp |= 1
+	}
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	if b.PTLPresentFlag {
+		buf := [3]uint8{
+			uint8(b.OLSIdx >> 1),
+			uint8(b.OLSIdx&1)<<7 | (b.NumSublayers&0b111)<<4 | (b.ConstantFrameRate&0b11)<<2 | b.ChromaFormatIdc&0b11,
+			(b.BitDepthMinus8&0b111)<<5 | 0b11111,
+		}
+		if err = binary.Write(w, binary.BigEndian, buf); err != nil {
+			return
+		}
+		if err = b.NativePTL.recordWrite(w, b.NumSublayers); err != nil {
+			return
+		}
+		if err = binary.Write(w, binary.BigEndian, [3]uint16{b.MaxPictureWidth, b.MaxPictureHeight, b.AvgFrameRate}); err != nil {
+			return
+		}
+	}
+	if len(b.NaluArrays) > 0xFF {
+		err = fmt.Errorf("VVC decoder configuration record has too many NAL unit arrays: %w", ErrInvalidFormat)
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint8(len(b.NaluArrays))); err != nil {
+		return
+	}
+	for _, entry := range b.NaluArrays {
+		tmp = uint8(entry.NALUnitType) & 0b11111
+		if entry.ArrayCompleteness {
+			tmp |= 0b10000000
+		}
+		if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+			return
+		}
+		if entry.NALUnitType.hasNumNalus() {
+			if err = binary.Write(w, binary.BigEndian, uint16(len(entry.NALUs))); err != nil {
+				return
+			}
+		} else if len(entry.NALUs) != 1 {
+			err = fmt.Errorf("VVC DCI and OPI NAL unit arrays must contain exactly one NAL unit: %w", ErrInvalidFormat)
+			return
+		}
+		for _, nalu := range entry.NALUs {
+			if err = binary.Write(w, binary.BigEndian, uint16(len(nalu))); err != nil {
+				return
+			}
+			if _, err = w.Write(nalu); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// WriteAnnexB writes the NAL units of all arrays in Annex B byte stream
+// format, each preceded by a four byte start code. Together with the output
+// of NewSampleToNALWriter for the samples, this forms a raw VVC bit stream.
+func (b *VVCDecoderConfigurationRecord) WriteAnnexB(w io.Writer) (err error) {
+	for _, entry := range b.NaluArrays {
+		for _, nalu := range entry.NALUs {
+			if _, err = w.Write([]byte{0, 0, 0, 1}); err != nil {
+				return
+			}
+			if _, err = w.Write(nalu); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (p *VVCPTLRecord) recordSize(numSublayers uint8) (size uint32) {
+	// bit(2) reserved = 0;
+	// unsigned int(6) num_bytes_constraint_info;
+	size += 1
+	// unsigned int(7) general_profile_idc;
+	// unsigned int(1) general_tier_flag;
+	size += 1
+	size += 1 // unsigned int(8) general_level_idc;
+	// unsigned int(1) ptl_frame_only_constraint_flag;
+	// unsigned int(1) ptl_multi_layer_enabled_flag;
+	// unsigned int(8*num_bytes_constraint_info - 2) general_constraint_info;
+	size += uint32(len(p.GeneralConstraintInfo))
+	if numSublayers > 1 {
+		// unsigned int(1) ptl_sublayer_level_present_flag[i];
+		// bit(1) ptl_reserved_zero_bit = 0;
+		size += 1
+		for _, level := range p.SublayerLevelIdc {
+			if level != 0 {
+				size += 1 // unsigned int(8) sublayer_level_idc[i];
+			}
+		}
+	}
+	size += 1                                       // unsigned int(8) ptl_num_sub_profiles;
+	size += 4 * uint32(len(p.GeneralSubProfileIdc)) // unsigned int(32) general_sub_profile_idc[j];
+	return
+}
+
+func (p *VVCPTLRecord) recordRead(r io.Reader, numSublayers uint8) (err error) {
+	var tmp [3]uint8
+	if err = binary.Read(r, binary.BigEndian, &tmp); err != nil {
+		return
+	}
+	numBytesConstraintInfo := tmp[0] & 0b111111
+	p.GeneralProfileIdc = tmp[1] >> 1
+	p.GeneralTierFlag = tmp[1]&1 != 0
+	p.GeneralLevelIdc = tmp[2]
+	if numBytesConstraintInfo == 0 {
+		err = fmt.Errorf("VVC PTL record has empty constraint info: %w", ErrInvalidFormat)
+		return
+	}
+	p.GeneralConstraintInfo = make([]byte, numBytesConstraintInfo)
+	if _, err = io.ReadFull(r, p.GeneralConstraintInfo); err != nil {
+		return
+	}
+	p.PTLFrameOnlyConstraintFlag = p.GeneralConstraintInfo[0]>>7 != 0
+	p.PTLMultiLayerEnabledFlag = p.GeneralConstraintInfo[0]>>6&1 != 0
+	p.GeneralConstraintInfo[0] &= 0b111111
+	p.SublayerLevelIdc = nil
+	if numSublayers > 1 {
+		var presentFlags uint8
+		if err = binary.Read(r, binary.BigEndian, &presentFlags); err != nil {
+			return
+		}
+		// the flags are stored from the highest sublayer down.
+		p.SublayerLevelIdc = make([]uint8, numSublayers-1)
+		levels := make([]uint8, bits.OnesCount8(presentFlags))
+		if err = binary.Read(r, binary.BigEndian, levels); err != nil {
+			return
+		}
+		for i := int(numSublayers) - 2; i >= 0; i-- {
+			if presentFlags>>(7-(int(numSublayers)-2-i))&1 != 0 {
+				p.SublayerLevelIdc[i] = levels[0]
+				levels = levels[1:]
+			}
+		}
+	}
+	var numSubProfiles uint8
+	if err = binary.Read(r, binary.BigEndian, &numSubProfiles); err != nil {
+		return
+	}
+	p.GeneralSubProfileIdc = make([]uint32, numSubProfiles)
+	if err = binary.Read(r, binary.BigEndian, p.GeneralSubProfileIdc); err != nil {
+		return
+	}
+	return
+}
+
+func (p *VVCPTLRecord) recordWrite(w io.Writer, numSublayers uint8) (err error) {
+	if len(p.GeneralConstraintInfo) == 0 || len(p.GeneralConstraintInfo) > 0b111111 {
+		err = fmt.Errorf("VVC PTL record constraint info must be 1 to 63 bytes: %w", ErrInvalidFormat)
+		return
+	}
+	var tierFlag uint8
+	if p.GeneralTierFlag {
+		tierFlag = 1
+	}
+	tmp := [3]uint8{uint8(len(p.GeneralConstraintInfo)), p.GeneralProfileIdc<<1 | tierFlag, p.GeneralLevelIdc}
+	if err = binary.Write(w, binary.BigEndian, tmp); err != nil {
+		return
+	}
+	constraintInfo := append([]byte(nil), p.GeneralConstraintInfo...)
+	constraintInfo[0] &= 0b111111
+	if p.PTLFrameOnlyConstraintFlag {
+		constraintInfo[0] |= 0b10000000
+	}
+	if p.PTLMultiLayerEnabledFlag {
+		constraintInfo[0] |= 0b01000000
+	}
+	if _, err = w.Write(constraintInfo); err != nil {
+		return
+	}
+	if numSublayers > 1 {
+		if len(p.SublayerLevelIdc) != int(numSublayers)-1 {
+			err = fmt.Errorf("VVC PTL record has %d sublayer levels for %d sublayers: %w", len(p.SublayerLevelIdc), numSublayers, ErrInvalidFormat)
+			return
+		}
+		var presentFlags uint8
+		var levels []uint8
+		for i := int(numSublayers) - 2; i >= 0; i-- {
+			if p.SublayerLevelIdc[i] != 0 {
+				presentFlags |= 1 << (7 - (int(numSublayers) - 2 - i))
+				levels = append(levels, p.SublayerLevelIdc[i])
+			}
+		}
+		if err = binary.Write(w, binary.BigEndian, presentFlags); err != nil {
+			return
+		}
+		if _, err = w.Write(levels); err != nil {
+			return
+		}
+	}
+	if len(p.GeneralSubProfileIdc) > 0xFF {
+		err = fmt.Errorf("VVC PTL record has too many sub profiles: %w", ErrInvalidFormat)
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint8(len(p.GeneralSubProfileIdc))); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, p.GeneralSubProfileIdc); err != nil {
+		return
+	}
+	return
+}
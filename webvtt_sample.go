@@ -0,0 +1,152 @@
+package mp4
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WebVTTSample is the decoded form of a sample of a WebVTT track. A sample
+// without cues is stored as a single VTTEmptyCueBox.
+type WebVTTSample struct {
+	// holds the text of the VTTAdditionalTextBoxes preceding the first cue.
+	AdditionalText []string
+
+	Cues []WebVTTCue
+}
+
+// WebVTTCue is a cue active during a sample, as stored in a VTTCueBox.
+type WebVTTCue struct {
+	// identifies the source of a cue split into several samples, if
+	// HasSourceID is set.
+	HasSourceID bool
+	SourceID    int32
+
+	ID          string
+	CurrentTime string
+	Settings    string
+	Payload     string
+
+	// holds the text of the VTTAdditionalTextBoxes following the cue.
+	AdditionalText []string
+}
+
+// DecodeWebVTTSample decodes the boxes of a WebVTT sample. Boxes of unknown
+// types are skipped.
+func DecodeWebVTTSample(data []byte) (sample *WebVTTSample, err error) {
+	sample = &WebVTTSample{}
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		var box Box
+		if box, err = readBoundedBox(r, uint64(r.Len())); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				err = fmt.Errorf("WebVTT sample has truncated box: %w", ErrInvalidFormat)
+			}
+			return
+		}
+		switch box := box.(type) {
+		case *VTTCueBox:
+			var cue WebVTTCue
+			if cue, err = decodeWebVTTCue(box); err != nil {
+				return
+			}
+			sample.Cues = append(sample.Cues, cue)
+		case *VTTAdditionalTextBox:
+			if len(sample.Cues) == 0 {
+				sample.AdditionalText = append(sample.AdditionalText, box.CueAdditionalText)
+			} else {
+				cue := &sample.Cues[len(sample.Cues)-1]
+				cue.AdditionalText = append(cue.AdditionalText, box.CueAdditionalText)
+			}
+		}
+	}
+	return
+}
+
+// readBoundedBox reads a box that must fit into the size bytes left in its
+// container, so that an invalid box size cannot make the box read beyond them.
+func readBoundedBox(r io.Reader, size uint64) (box Box, err error) {
+	var header *Header
+	if header, err = ReadHeader(r); err != nil {
+		return
+	}
+	if header.Size < header.HeaderSize() || uint64(header.Size) > size {
+		err = fmt.Errorf("%s box has invalid size %d: %w", header.Type, header.Size, ErrInvalidFormat)
+		return
+	}
+	return ReadBoxAfterHeader(r, header)
+}
+
+func decodeWebVTTCue(box *VTTCueBox) (cue WebVTTCue, err error) {
+	var hasPayload bool
+	for _, child := range box.Mp4BoxChildren() {
+		switch child := child.(type) {
+		case *CueSourceIDBox:
+			cue.HasSourceID = true
+			cue.SourceID = child.SourceID
+		case *CueIDBox:
+			cue.ID = child.CueID
+		case *CueTimeBox:
+			cue.CurrentTime = child.CueCurrentTime
+		case *CueSettingsBox:
+			cue.Settings = child.Settings
+		case *CuePayloadBox:
+			cue.Payload = child.CuePayload
+			hasPayload = true
+		}
+	}
+	if !hasPayload {
+		err = fmt.Errorf("vttc box without payl box: %w", ErrInvalidFormat)
+		return
+	}
+	return
+}
+
+// Boxes returns the boxes of the sample in storage order. A sample without
+// cues and additional text is encoded as a VTTEmptyCueBox. Empty optional
+// strings of a cue are omitted.
+func (sample *WebVTTSample) Boxes() (boxes []Box) {
+	for _, text := range sample.AdditionalText {
+		boxes = append(boxes, &VTTAdditionalTextBox{CueAdditionalText: text})
+	}
+	for i := range sample.Cues {
+		cue := &sample.Cues[i]
+		vttc := &VTTCueBox{}
+		if cue.HasSourceID {
+			vttc.Mp4BoxAppend(&CueSourceIDBox{SourceID: cue.SourceID})
+		}
+		if cue.ID != "" {
+			vttc.Mp4BoxAppend(&CueIDBox{CueID: cue.ID})
+		}
+		if cue.CurrentTime != "" {
+			vttc.Mp4BoxAppend(&CueTimeBox{CueCurrentTime: cue.CurrentTime})
+		}
+		if cue.Settings != "" {
+			vttc.Mp4BoxAppend(&CueSettingsBox{Settings: cue.Settings})
+		}
+		vttc.Mp4BoxAppend(&CuePayloadBox{CuePayload: cue.Payload})
+		boxes = append(boxes, vttc)
+		for _, text := range cue.AdditionalText {
+			boxes = append(boxes, &VTTAdditionalTextBox{CueAdditionalText: text})
+		}
+	}
+	if len(boxes) == 0 {
+		boxes = append(boxes, &VTTEmptyCueBox{})
+	}
+	return
+}
+
+// EncodeWebVTTSample encodes the sample into the bytes of a sample of a
+// WebVTT track.
+func EncodeWebVTTSample(sample *WebVTTSample) (data []byte, err error) {
+	var buf bytes.Buffer
+	for _, box := range sample.Boxes() {
+		box.Mp4BoxUpdate()
+		if err = box.Mp4BoxWrite(&buf); err != nil {
+			return
+		}
+	}
+	data = buf.Bytes()
+	return
+}
@@ -0,0 +1,40 @@
+package mp4
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeWebVTTSample(t *testing.T) {
+	sample := &WebVTTSample{Cues: []WebVTTCue{{ID: "1", Payload: "Hello"}}}
+	data, err := EncodeWebVTTSample(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeWebVTTSample(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Cues) != 1 || decoded.Cues[0].ID != "1" || decoded.Cues[0].Payload != "Hello" {
+		t.Errorf("sample is decoded as %+v", decoded)
+	}
+}
+
+func TestDecodeWebVTTSampleInvalidSize(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data []byte
+	}{
+		{"smaller than header", []byte{0, 0, 0, 4, 'z', 'z', 'z', 'z'}},
+		{"larger than sample", []byte{0, 0, 0, 16, 'z', 'z', 'z', 'z', 0, 0}},
+		{"cue smaller than header", []byte{0, 0, 0, 4, 'v', 't', 't', 'c'}},
+		{"cue child smaller than header", []byte{0, 0, 0, 16, 'v', 't', 't', 'c', 0, 0, 0, 4, 'z', 'z', 'z', 'z'}},
+		{"cue child larger than cue", []byte{0, 0, 0, 16, 'v', 't', 't', 'c', 0, 0, 0, 12, 'z', 'z', 'z', 'z', 0, 0, 0, 0}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := DecodeWebVTTSample(test.data); !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("got error %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
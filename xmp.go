@@ -0,0 +1,144 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+)
+
+// ParseXMPProperties parses an XMP packet into a map of simple properties,
+// keyed by the namespace prefix and name of the property, e.g.
+// ‘xmp:CreatorTool’ or ‘dc:title’. Properties may be given as attributes or
+// child elements of rdf:Description. For arrays (rdf:Alt, rdf:Seq and rdf:Bag)
+// the first item is used, and for resource references the rdf:resource
+// attribute. Fields of structured properties are added under their own names.
+func ParseXMPProperties(packet []byte) (properties map[string]string, err error) {
+	type frame struct {
+		description bool
+		property    string
+		text        strings.Builder
+		done        bool
+	}
+	properties = make(map[string]string)
+	prefixes := make(map[string]string)
+	key := func(name xml.Name) string {
+		if prefix, ok := prefixes[name.Space]; ok {
+			return prefix + ":" + name.Local
+		}
+		if name.Space == "" {
+			return name.Local
+		}
+		return name.Space + ":" + name.Local
+	}
+	set := func(name, value string) {
+		if _, ok := properties[name]; !ok && value != "" {
+			properties[name] = value
+		}
+	}
+	var stack []*frame
+	// innermost returns the innermost property frame, or nil.
+	innermost := func() *frame {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].property != "" {
+				return stack[i]
+			}
+		}
+		return nil
+	}
+	d := xml.NewDecoder(bytes.NewReader(packet))
+	for {
+		token, tokenErr := d.Token()
+		if tokenErr != nil {
+			if !errors.Is(tokenErr, io.EOF) {
+				err = tokenErr
+			}
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					prefixes[attr.Value] = attr.Name.Local
+				}
+			}
+			f := &frame{}
+			var parent *frame
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+			if t.Name.Space == rdfNamespace && t.Name.Local == "Description" {
+				f.description = true
+				for _, attr := range t.Attr {
+					if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" ||
+						attr.Name.Space == rdfNamespace || attr.Name.Space == xmlNamespace || attr.Name.Space == "xml" {
+						continue
+					}
+					set(key(attr.Name), strings.TrimSpace(attr.Value))
+				}
+			} else if parent != nil && parent.description {
+				f.property = key(t.Name)
+				for _, attr := range t.Attr {
+					if attr.Name.Space == rdfNamespace && attr.Name.Local == "resource" {
+						set(f.property, attr.Value)
+					}
+				}
+			}
+			stack = append(stack, f)
+		case xml.CharData:
+			if f := innermost(); f != nil && !f.done {
+				f.text.Write(t)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				break
+			}
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if f.property != "" {
+				set(f.property, strings.TrimSpace(f.text.String()))
+			} else if t.Name.Space == rdfNamespace && t.Name.Local == "li" {
+				if p := innermost(); p != nil {
+					p.done = true
+				}
+			}
+		}
+	}
+	return
+}
+
+// PadXMPPacket pads the XMP packet with whitespace to size bytes, which is how
+// XMP packets are kept editable in place. The padding is inserted before the
+// packet trailer ‘<?xpacket end=…?>’, or appended if there is none. The packet
+// is returned unchanged if it is not smaller than size.
+func PadXMPPacket(packet []byte, size int) []byte {
+	if len(packet) >= size {
+		return packet
+	}
+	padding := make([]byte, size-len(packet))
+	for i := range padding {
+		if i%100 == 99 {
+			padding[i] = '\n'
+		} else {
+			padding[i] = ' '
+		}
+	}
+	if len(padding) > 0 {
+		padding[len(padding)-1] = '\n'
+	}
+	at := bytes.LastIndex(packet, []byte("<?xpacket end="))
+	if at < 0 {
+		at = len(packet)
+	}
+	padded := make([]byte, 0, size)
+	padded = append(padded, packet[:at]...)
+	padded = append(padded, padding...)
+	padded = append(padded, packet[at:]...)
+	return padded
+}